@@ -3,6 +3,7 @@ package haresheet
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"google.golang.org/api/sheets/v4"
@@ -55,7 +56,13 @@ type BatchUpdateExecutor struct {
 	units    []*RequestUnitInfo
 	limit    int
 	err      error
+	replies  []*sheets.Response // replies of flushes triggered by Queue, returned by the next FlushWithResult
 	Trace    *ClientTrace
+
+	// ReuseBuffers keeps the buffers' capacity after Flush instead of releasing them.
+	// Useful when flushing repeatedly; the default releases memory after one-shot use.
+	// Trace hooks then receive a copy of the units, so they may keep FlushStatus.
+	ReuseBuffers bool
 }
 
 // NewBatchUpdateExecutor
@@ -83,13 +90,17 @@ func (e *BatchUpdateExecutor) Queue(ctx context.Context, reqs []*sheets.Request,
 	newCount := len(reqs)
 
 	if currentCount > 0 && (currentCount+newCount) > e.limit {
-		err := e.Flush(ctx)
+		resp, err := e.flush(ctx)
 		if err != nil {
 			e.err = err
 
 			return e
 		}
 
+		if resp != nil {
+			e.replies = append(e.replies, resp.Replies...)
+		}
+
 		currentCount = 0
 	}
 
@@ -112,10 +123,58 @@ func (e *BatchUpdateExecutor) Queue(ctx context.Context, reqs []*sheets.Request,
 	return e
 }
 
+// BatchUpdate queues requests and flushes them immediately, implementing SheetWriter.
+func (e *BatchUpdateExecutor) BatchUpdate(ctx context.Context, requests []*sheets.Request) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	e.Queue(ctx, requests, "")
+
+	if e.err != nil {
+		return fmt.Errorf("BatchUpdate: %w", e.err)
+	}
+
+	if err := e.Flush(ctx); err != nil {
+		return fmt.Errorf("BatchUpdate: %w", err)
+	}
+
+	return nil
+}
+
 // Flush
 func (e *BatchUpdateExecutor) Flush(ctx context.Context) error {
+	_, err := e.FlushWithResult(ctx)
+
+	return err
+}
+
+// FlushWithResult is like Flush but also returns the batch response.
+// Replies of batches already sent by Queue since the last flush are included in order.
+func (e *BatchUpdateExecutor) FlushWithResult(ctx context.Context) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	resp, err := e.flush(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sheets.BatchUpdateSpreadsheetResponse{
+		SpreadsheetId: e.spreadID,
+		Replies:       e.replies,
+	}
+
+	if resp != nil {
+		result.Replies = append(result.Replies, resp.Replies...)
+	}
+
+	e.replies = nil
+
+	return result, nil
+}
+
+// flush sends the queued requests. It returns a nil response if nothing is queued.
+func (e *BatchUpdateExecutor) flush(ctx context.Context) (*sheets.BatchUpdateSpreadsheetResponse, error) {
 	if len(e.requests) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	labels := make([]string, 0, len(e.units))
@@ -124,9 +183,16 @@ func (e *BatchUpdateExecutor) Flush(ctx context.Context) error {
 		labels = append(labels, u.Label)
 	}
 
+	units := e.units
+
+	if e.ReuseBuffers && e.Trace != nil {
+		// 送信後に e.units を使い回すので、トレース側が保持しても壊れないようコピーを渡す
+		units = slices.Clone(e.units)
+	}
+
 	status := &FlushStatus{
 		RequestCount:  len(e.requests),
-		Units:         e.units,
+		Units:         units,
 		SpreadsheetID: e.spreadID,
 	}
 
@@ -136,7 +202,7 @@ func (e *BatchUpdateExecutor) Flush(ctx context.Context) error {
 
 	start := time.Now()
 
-	_, err := e.service.Spreadsheets.BatchUpdate(e.spreadID, &sheets.BatchUpdateSpreadsheetRequest{
+	resp, err := e.service.Spreadsheets.BatchUpdate(e.spreadID, &sheets.BatchUpdateSpreadsheetRequest{
 		Requests: e.requests,
 	}).Context(ctx).Do()
 
@@ -148,15 +214,26 @@ func (e *BatchUpdateExecutor) Flush(ctx context.Context) error {
 	}
 
 	if err != nil {
-		return &BatchUpdateError{
+		return nil, &BatchUpdateError{
 			Err:   err,
 			Units: e.units,
 		}
 	}
 
+	if e.ReuseBuffers {
+		// 容量は残しつつ、参照は切って GC できるようにする
+		clear(e.requests)
+		clear(e.units)
+
+		e.requests = e.requests[:0]
+		e.units = e.units[:0]
+
+		return resp, nil
+	}
+
 	// バッファが大きくなりすぎた場合に解放するためnilをいれる
 	e.requests = nil
 	e.units = nil
 
-	return nil
+	return resp, nil
 }
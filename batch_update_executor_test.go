@@ -0,0 +1,58 @@
+package haresheet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestReuseBuffersTraceKeepsUnits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"replies":[{}]}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	svc, err := sheets.NewService(ctx, option.WithEndpoint(srv.URL), option.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatalf("NewService() error = %v", err)
+	}
+
+	var kept []*FlushStatus
+
+	e := NewBatchUpdateExecutor(svc, "test", 100)
+	e.ReuseBuffers = true
+	e.Trace = &ClientTrace{
+		OnFlushDone: func(_ context.Context, status *FlushStatus, _ time.Duration, _ error) {
+			kept = append(kept, status)
+		},
+	}
+
+	req := &sheets.Request{UpdateCells: &sheets.UpdateCellsRequest{Fields: "userEnteredValue"}}
+
+	for _, label := range []string{"first", "second"} {
+		e.Queue(ctx, []*sheets.Request{req}, label)
+
+		if err := e.Flush(ctx); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+	}
+
+	if len(kept) != 2 {
+		t.Fatalf("OnFlushDone called %d times, want 2", len(kept))
+	}
+
+	for i, want := range []string{"first", "second"} {
+		units := kept[i].Units
+		if len(units) != 1 || units[0] == nil || units[0].Label != want {
+			t.Errorf("kept[%d].Units = %v, want one unit labelled %q", i, units, want)
+		}
+	}
+}
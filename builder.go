@@ -2,8 +2,12 @@ package haresheet
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"google.golang.org/api/sheets/v4"
@@ -13,10 +17,20 @@ import (
 type Builder struct {
 	executor *BatchUpdateExecutor
 	requests []*sheets.Request
+	prepends []*sheets.Request // stored in call order, emitted in reverse
 	errs     []error
 
 	props      *sheets.SpreadsheetProperties
 	propFields []string // "title", "locale", etc...
+
+	streamCtx context.Context // non-nil in streaming mode
+	streamed  int             // number of intermediate flushes since the last Reset
+
+	rangeSlab []sheets.GridRange // unused GridRanges handed out by newGridRange
+
+	defaultPasteType PasteType
+	skipEmpty        bool
+	inputMode        InputMode
 }
 
 // NewBuilder creates a new Builder instance.
@@ -29,13 +43,26 @@ func NewBuilder() *Builder {
 	}
 }
 
-// Requests
+// Requests returns the pending requests in execution order, which is exactly what Flush sends:
+// the spreadsheet-properties update, then prepended requests, then appended requests.
+// Prepended requests come in reverse call order (the most recent first); appended requests
+// keep call order. The order depends only on the call sequence, so the result can be compared
+// in golden-file tests. It returns an error if a request references a sheet that is added later in the batch.
 func (b *Builder) Requests() ([]*sheets.Request, error) {
 	if len(b.errs) > 0 {
 		return nil, errors.Join(b.errs...)
 	}
 
-	finalRequests := b.requests
+	if len(b.prepends) == 0 && (b.props == nil || len(b.propFields) == 0) {
+		if err := checkSheetCreationOrder(b.requests); err != nil {
+			return nil, err
+		}
+
+		return b.requests, nil
+	}
+
+	// * create new slice
+	finalRequests := make([]*sheets.Request, 0, 1+len(b.prepends)+len(b.requests))
 
 	if b.props != nil && len(b.propFields) > 0 {
 		req := &sheets.Request{
@@ -45,29 +72,156 @@ func (b *Builder) Requests() ([]*sheets.Request, error) {
 			},
 		}
 
-		// * create new slice
-		finalRequests = append([]*sheets.Request{req}, finalRequests...)
+		finalRequests = append(finalRequests, req)
+	}
+
+	// 後から Prepend されたものほど前に来る
+	for i := len(b.prepends) - 1; i >= 0; i-- {
+		finalRequests = append(finalRequests, b.prepends[i])
+	}
+
+	finalRequests = append(finalRequests, b.requests...)
+
+	if err := checkSheetCreationOrder(finalRequests); err != nil {
+		return nil, err
 	}
 
 	return finalRequests, nil
 }
 
-// AppendRequest
+// RangeSummary returns the A1 description of every range the pending requests touch,
+// in request order, without executing them. Requests without a range are skipped.
+func (b *Builder) RangeSummary() ([]string, error) {
+	requests, err := b.Requests()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := make([]string, 0, len(requests))
+
+	for _, req := range requests {
+		walkRequestRanges(req, func(a1 string) {
+			summary = append(summary, a1)
+		})
+	}
+
+	return summary, nil
+}
+
+// Fingerprint returns a stable hash of the pending requests.
+// Identical batches produce identical fingerprints, so it can be stored to skip re-sending a batch.
+func (b *Builder) Fingerprint() (string, error) {
+	requests, err := b.Requests()
+	if err != nil {
+		return "", err
+	}
+
+	// 構造体のフィールド順で出力されるので、同じリクエストなら同じ JSON になる
+	data, err := json.Marshal(requests)
+	if err != nil {
+		return "", fmt.Errorf("Fingerprint: failed to marshal requests: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AppendRequest queues a request to run after all previously queued requests.
+// In streaming mode, once an error has been recorded nothing can be sent any more,
+// so further requests are dropped instead of buffered; Flush reports the errors.
 func (b *Builder) AppendRequest(request *sheets.Request) {
 	if request == nil {
 		return
 	}
 
+	if b.streamFailed() {
+		return
+	}
+
 	b.requests = append(b.requests, request)
+
+	if b.streamCtx != nil && b.executor != nil && len(b.requests) >= b.executor.limit {
+		b.stream()
+	}
+}
+
+// streamFailed reports whether the builder is in streaming mode and has recorded an error.
+func (b *Builder) streamFailed() bool {
+	return b.streamCtx != nil && len(b.errs) > 0
 }
 
-// PrependRequest
+// stream sends the pending requests to the executor in streaming mode.
+// On failure the error is recorded and the pending requests are discarded, since the batch
+// can no longer be sent as a whole.
+func (b *Builder) stream() {
+	requests, err := b.Requests()
+	if err != nil {
+		b.appendError(fmt.Errorf("stream: %w", err))
+		b.dropPending()
+
+		return
+	}
+
+	b.executor.Queue(b.streamCtx, requests, "")
+
+	err = b.executor.Flush(b.streamCtx)
+	if err != nil {
+		b.appendError(fmt.Errorf("stream: failed to flush requests: %w", err))
+		b.dropPending()
+
+		return
+	}
+
+	b.streamed++
+
+	b.dropPending()
+}
+
+// dropPending clears the pending requests and properties, keeping errors and buffer capacity.
+func (b *Builder) dropPending() {
+	// executor 側にコピー済みなので容量は再利用する
+	b.requests = b.requests[:0]
+	b.prepends = nil
+	b.props = nil
+	b.propFields = b.propFields[:0]
+}
+
+// PrependRequest queues a request to run before all appended requests.
+// Prepended requests are kept separately and joined once in Requests, so repeated
+// calls stay cheap. As before, the most recently prepended request comes first.
+//
+// In streaming mode a request cannot be placed ahead of requests that were already sent,
+// so prepending after an intermediate flush records an error.
 func (b *Builder) PrependRequest(request *sheets.Request) {
-	if request == nil {
+	if request == nil || b.streamFailed() {
+		return
+	}
+
+	if b.streamed > 0 {
+		b.appendError(errors.New("PrependRequest: cannot prepend after requests have been streamed"))
+
 		return
 	}
 
-	b.requests = append([]*sheets.Request{request}, b.requests...)
+	b.prepends = append(b.prepends, request)
+}
+
+// gridRangeChunk is the number of GridRanges allocated at once by newGridRange.
+const gridRangeChunk = 256
+
+// newGridRange returns a zeroed GridRange carved from a chunk, so that building many
+// requests allocates one chunk per gridRangeChunk ranges instead of one per range.
+// Handed-out ranges are never reused; a chunk is freed once no request refers to it.
+func (b *Builder) newGridRange() *sheets.GridRange {
+	if len(b.rangeSlab) == 0 {
+		b.rangeSlab = make([]sheets.GridRange, gridRangeChunk)
+	}
+
+	rng := &b.rangeSlab[0]
+	b.rangeSlab = b.rangeSlab[1:]
+
+	return rng
 }
 
 // addError appends an error to the list.
@@ -77,6 +231,14 @@ func (b *Builder) appendError(err error) {
 	}
 }
 
+// wrapErrorsSince prefixes the errors recorded after the first n with label,
+// so errors from methods used by a composite method are reported under its name.
+func (b *Builder) wrapErrorsSince(n int, label string) {
+	for i := n; i < len(b.errs); i++ {
+		b.errs[i] = fmt.Errorf("%s: %w", label, b.errs[i])
+	}
+}
+
 func (b *Builder) ensureProps() {
 	if b.props == nil {
 		b.props = &sheets.SpreadsheetProperties{}
@@ -111,6 +273,64 @@ func (b *Builder) TimeZone(tz string) *Builder {
 	return b
 }
 
+// autoRecalc sets how often volatile functions (NOW, RAND, etc.) are recalculated.
+func (b *Builder) autoRecalc(recalc string) *Builder {
+	b.ensureProps()
+	b.props.AutoRecalc = recalc
+	b.propFields = append(b.propFields, "autoRecalc")
+
+	return b
+}
+
+// RecalcOnChange recalculates volatile functions on every change.
+func (b *Builder) RecalcOnChange() *Builder {
+	return b.autoRecalc("ON_CHANGE")
+}
+
+// RecalcEveryMinute recalculates volatile functions on every change and every minute.
+func (b *Builder) RecalcEveryMinute() *Builder {
+	return b.autoRecalc("MINUTE")
+}
+
+// RecalcEveryHour recalculates volatile functions on every change and every hour.
+func (b *Builder) RecalcEveryHour() *Builder {
+	return b.autoRecalc("HOUR")
+}
+
+// IterativeCalculation enables iterative calculation for circular references.
+func (b *Builder) IterativeCalculation(maxIterations int, convergenceThreshold float64) *Builder {
+	if maxIterations < 1 {
+		b.appendError(fmt.Errorf("IterativeCalculation: invalid maxIterations: %d", maxIterations))
+
+		return b
+	}
+
+	if convergenceThreshold < 0 {
+		b.appendError(fmt.Errorf("IterativeCalculation: invalid convergenceThreshold: %v", convergenceThreshold))
+
+		return b
+	}
+
+	b.ensureProps()
+	b.props.IterativeCalculationSettings = &sheets.IterativeCalculationSettings{
+		MaxIterations:        int64(maxIterations),
+		ConvergenceThreshold: convergenceThreshold,
+		ForceSendFields:      []string{"ConvergenceThreshold"},
+	}
+	b.propFields = append(b.propFields, "iterativeCalculationSettings")
+
+	return b
+}
+
+// DisableIterativeCalculation disables iterative calculation.
+func (b *Builder) DisableIterativeCalculation() *Builder {
+	b.ensureProps()
+	b.props.IterativeCalculationSettings = nil
+	b.propFields = append(b.propFields, "iterativeCalculationSettings")
+
+	return b
+}
+
 // Sheet
 func (b *Builder) Sheet(sheetID int64) *SheetBuilder {
 	sb := &SheetBuilder{
@@ -127,6 +347,33 @@ func (b *Builder) Sheet(sheetID int64) *SheetBuilder {
 	return sb
 }
 
+// Sheets returns a SheetBuilder for each of the given sheet IDs.
+func (b *Builder) Sheets(sheetIDs ...int64) []*SheetBuilder {
+	sbs := make([]*SheetBuilder, 0, len(sheetIDs))
+
+	for _, id := range sheetIDs {
+		sbs = append(sbs, b.Sheet(id))
+	}
+
+	return sbs
+}
+
+// ForEachSheet calls fn with a SheetBuilder for each of the given sheet IDs.
+// Use it to apply the same operations to many sheets.
+func (b *Builder) ForEachSheet(sheetIDs []int64, fn func(sb *SheetBuilder)) *Builder {
+	if fn == nil {
+		b.appendError(errors.New("ForEachSheet: fn should not be nil"))
+
+		return b
+	}
+
+	for _, sb := range b.Sheets(sheetIDs...) {
+		fn(sb)
+	}
+
+	return b
+}
+
 // AddSheet adds a request to create a new sheet with a SPECIFIC ID and INDEX.
 // Pass index: -1 to append to the end.
 func (b *Builder) AddSheet(sheetID int64, title string, index int) *Builder {
@@ -146,7 +393,7 @@ func (b *Builder) AddSheet(sheetID int64, title string, index int) *Builder {
 		},
 	}
 
-	b.requests = append(b.requests, req)
+	b.AppendRequest(req)
 
 	return b
 }
@@ -242,6 +489,55 @@ func (b *Builder) CopySheetWithID(srcSheetID int64, toIndex int, newSheetID int6
 	return b
 }
 
+// CopySheetFormat copies the formatting of the whole src sheet onto the dst sheet.
+// Values are not copied. If the grid sizes differ, use SheetClient.CopyFormatFrom
+// to cap the copied area to the destination's grid.
+func (b *Builder) CopySheetFormat(srcSheetID int64, dstSheetID int64) *Builder {
+	if srcSheetID < 0 {
+		b.appendError(fmt.Errorf("CopySheetFormat: invalid src sheet id: %d", srcSheetID))
+
+		return b
+	}
+
+	if dstSheetID < 0 {
+		b.appendError(fmt.Errorf("CopySheetFormat: invalid dst sheet id: %d", dstSheetID))
+
+		return b
+	}
+
+	return b.copySheetFormat(srcSheetID, dstSheetID, rangeUnset, rangeUnset)
+}
+
+// copySheetFormat copies formatting from the top-left rows x cols area of src to dst.
+// If rows or cols is rangeUnset, the corresponding dimension is left open.
+func (b *Builder) copySheetFormat(srcSheetID int64, dstSheetID int64, rows int, cols int) *Builder {
+	srcRange := &sheets.GridRange{SheetId: srcSheetID}
+	dstRange := &sheets.GridRange{SheetId: dstSheetID}
+
+	if rows != rangeUnset {
+		srcRange.EndRowIndex = int64(rows)
+		dstRange.EndRowIndex = int64(rows)
+	}
+
+	if cols != rangeUnset {
+		srcRange.EndColumnIndex = int64(cols)
+		dstRange.EndColumnIndex = int64(cols)
+	}
+
+	req := &sheets.Request{
+		CopyPaste: &sheets.CopyPasteRequest{
+			Source:           srcRange,
+			Destination:      dstRange,
+			PasteType:        string(PasteTypeFormat),
+			PasteOrientation: "NORMAL",
+		},
+	}
+
+	b.AppendRequest(req)
+
+	return b
+}
+
 // DeleteSheet deletes one or more sheets by their IDs.
 func (b *Builder) DeleteSheet(sheetIDs ...int64) *Builder {
 	if len(sheetIDs) == 0 {
@@ -261,6 +557,95 @@ func (b *Builder) DeleteSheet(sheetIDs ...int64) *Builder {
 	return b
 }
 
+// SetTabColors sets the tab color of each sheet in colors, keyed by sheet ID.
+// Requests are emitted in ascending sheet ID order.
+func (b *Builder) SetTabColors(colors map[int64]*sheets.Color) *Builder {
+	ids := make([]int64, 0, len(colors))
+
+	for id := range colors {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		if colors[id] == nil {
+			b.appendError(fmt.Errorf("SetTabColors: color for sheet %d should not be nil", id))
+
+			continue
+		}
+
+		b.Sheet(id).SetTabColor(colors[id])
+	}
+
+	return b
+}
+
+// DefaultPasteType sets the paste type used by copy and fill methods when an empty PasteType is passed.
+// Without it, PasteTypeNormal is used.
+func (b *Builder) DefaultPasteType(pt PasteType) *Builder {
+	b.defaultPasteType = pt
+
+	return b
+}
+
+// SkipEmpty controls whether SetRowValues, SetColValues and SetRangeValues skip empty cells
+// ("" or nil). Skipped cells are left unchanged rather than cleared; use ClearRangeValues
+// to clear cells. Each run of consecutive non-empty cells becomes a separate request.
+func (b *Builder) SkipEmpty(skip bool) *Builder {
+	b.skipEmpty = skip
+
+	return b
+}
+
+// InputMode sets how strings passed to the value setters (SetCellValue, SetRowValues, etc.) are interpreted.
+// In InputModeUserEntered (the default) a string starting with "=" is written as a formula.
+// In InputModeRaw every string is written as text, which is safer for untrusted data.
+// Strings are never parsed as numbers or dates in either mode; numbers and bools are written as is.
+func (b *Builder) InputMode(mode InputMode) *Builder {
+	if mode != InputModeUserEntered && mode != InputModeRaw {
+		b.appendError(fmt.Errorf("InputMode: invalid mode: %q", mode))
+
+		return b
+	}
+
+	b.inputMode = mode
+
+	return b
+}
+
+// pasteTypeOr returns pt, or the builder's default paste type if pt is empty.
+func (b *Builder) pasteTypeOr(pt PasteType) PasteType {
+	if pt != "" {
+		return pt
+	}
+
+	if b.defaultPasteType != "" {
+		return b.defaultPasteType
+	}
+
+	return PasteTypeNormal
+}
+
+// WithExecutor sets the executor used by Flush, so that a builder created by NewBuilder
+// can be flushed later. A nil executor detaches it.
+func (b *Builder) WithExecutor(e *BatchUpdateExecutor) *Builder {
+	b.executor = e
+
+	return b
+}
+
+// AttachClient makes Flush send the requests to c's spreadsheet.
+func (b *Builder) AttachClient(c *Client) *Builder {
+	if c == nil {
+		b.appendError(errors.New("AttachClient: client should not be nil"))
+
+		return b
+	}
+
+	return b.WithExecutor(NewBatchUpdateExecutor(c.service, c.spreadID, 100))
+}
+
 // WithTrace sets the tracer to the underlying executor.
 func (b *Builder) WithTrace(trace *ClientTrace) *Builder {
 	if b.executor != nil {
@@ -281,35 +666,113 @@ func (b *Builder) WithLimit(limit int) *Builder {
 
 // Flush executes the batched requests.
 func (b *Builder) Flush(ctx context.Context) error {
+	_, err := b.flush(ctx, "Flush")
+
+	return err
+}
+
+// FlushWithResult is like Flush but also returns the batch response, whose replies hold
+// server-assigned values such as new sheet IDs. Replies of all chunks are concatenated in order.
+// In streaming mode, replies of requests already sent by intermediate flushes are not included.
+// If there is nothing to send, it returns a nil response.
+func (b *Builder) FlushWithResult(ctx context.Context) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	return b.flush(ctx, "FlushWithResult")
+}
+
+// flush sends the pending requests and resets the builder. label prefixes returned errors.
+func (b *Builder) flush(ctx context.Context, label string) (*sheets.BatchUpdateSpreadsheetResponse, error) {
 	requests, err := b.Requests()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(requests) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	if b.executor == nil {
-		return fmt.Errorf("Flush: cannot flush builder without a client")
+		return nil, fmt.Errorf("%s: cannot flush builder without a client; create it with Client.Builder or attach one with AttachClient or WithExecutor", label)
 	}
 
 	b.executor.Queue(ctx, requests, "")
 
-	err = b.executor.Flush(ctx)
+	resp, err := b.executor.FlushWithResult(ctx)
 	if err != nil {
-		return fmt.Errorf("Flush: failed to flush builder: %w", err)
+		return nil, fmt.Errorf("%s: failed to flush builder: %w", label, err)
 	}
 
 	b.Reset()
 
-	return nil
+	return resp, nil
+}
+
+// BuilderSnapshot is a saved state of a Builder's pending requests, errors and properties.
+type BuilderSnapshot struct {
+	requests   []*sheets.Request
+	prepends   []*sheets.Request
+	errs       []error
+	props      *sheets.SpreadsheetProperties
+	propFields []string
+	streamed   int
+}
+
+// Snapshot saves the current pending state so it can be restored later with Restore.
+// Queued requests are shared, not deep-copied, as the builder never modifies them.
+func (b *Builder) Snapshot() *BuilderSnapshot {
+	s := &BuilderSnapshot{
+		requests:   slices.Clone(b.requests),
+		prepends:   slices.Clone(b.prepends),
+		errs:       slices.Clone(b.errs),
+		propFields: slices.Clone(b.propFields),
+		streamed:   b.streamed,
+	}
+
+	if b.props != nil {
+		props := *b.props
+		s.props = &props
+	}
+
+	return s
+}
+
+// Restore rolls the builder back to the state saved in s.
+// The snapshot is left unchanged and can be restored again.
+//
+// In streaming mode, requests sent since the snapshot cannot be taken back, so restoring
+// a snapshot taken before an intermediate flush records an error instead.
+func (b *Builder) Restore(s *BuilderSnapshot) *Builder {
+	if s == nil {
+		b.appendError(errors.New("Restore: snapshot should not be nil"))
+
+		return b
+	}
+
+	if s.streamed != b.streamed {
+		b.appendError(errors.New("Restore: requests have been streamed since the snapshot was taken"))
+
+		return b
+	}
+
+	b.requests = slices.Clone(s.requests)
+	b.prepends = slices.Clone(s.prepends)
+	b.errs = slices.Clone(s.errs)
+	b.propFields = slices.Clone(s.propFields)
+	b.props = nil
+
+	if s.props != nil {
+		props := *s.props
+		b.props = &props
+	}
+
+	return b
 }
 
 // Reset clears the pending requests in the builder.
 func (b *Builder) Reset() {
 	b.requests = make([]*sheets.Request, 0)
+	b.prepends = nil
 	b.errs = make([]error, 0)
 	b.props = nil
 	b.propFields = make([]string, 0)
+	b.streamed = 0
 }
@@ -0,0 +1,140 @@
+package haresheet
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// BenchmarkBuilderLargeBatch builds 10k range requests and 10k prepended dimension requests,
+// the workload of a structural-heavy generator.
+func BenchmarkBuilderLargeBatch(b *testing.B) {
+	color := MustParseHexColor("#FFEEDD")
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		sb := NewBuilder().Sheet(0)
+
+		for i := range 10000 {
+			sb.SetBackgroundColor(&Rect{Row: i, Col: 0, Height: 1, Width: 5}, color)
+			sb.ExpandRows(1)
+		}
+
+		if _, err := sb.Requests(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestPrependRequestOrder(t *testing.T) {
+	sb := NewBuilder().Sheet(0)
+
+	sb.SetCellValue(0, 0, "a")
+	sb.ExpandRows(1)
+	sb.ExpandColumns(2)
+
+	reqs, err := sb.Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	if len(reqs) != 3 {
+		t.Fatalf("len(Requests()) = %d, want 3", len(reqs))
+	}
+
+	// 後から Prepend されたものほど前に来る
+	if reqs[0].AppendDimension == nil || reqs[0].AppendDimension.Dimension != "COLUMNS" {
+		t.Errorf("Requests()[0] = %+v, want the ExpandColumns request", reqs[0])
+	}
+
+	if reqs[1].AppendDimension == nil || reqs[1].AppendDimension.Dimension != "ROWS" {
+		t.Errorf("Requests()[1] = %+v, want the ExpandRows request", reqs[1])
+	}
+
+	if reqs[2].UpdateCells == nil {
+		t.Errorf("Requests()[2] = %+v, want the SetCellValue request", reqs[2])
+	}
+}
+
+func TestRequestsOrderContract(t *testing.T) {
+	b := NewBuilder().Title("report")
+	sb := b.Sheet(0)
+
+	sb.SetCellValue(0, 0, "a")
+	sb.ExpandRows(1)
+	sb.SetCellValue(1, 0, "b")
+	sb.ExpandColumns(2)
+
+	reqs, err := b.Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	if len(reqs) != 5 {
+		t.Fatalf("len(Requests()) = %d, want 5", len(reqs))
+	}
+
+	if reqs[0].UpdateSpreadsheetProperties == nil {
+		t.Errorf("Requests()[0] = %+v, want the spreadsheet-properties update", reqs[0])
+	}
+
+	// 後から Prepend されたものほど前に来る
+	if reqs[1].AppendDimension == nil || reqs[1].AppendDimension.Dimension != "COLUMNS" {
+		t.Errorf("Requests()[1] = %+v, want the ExpandColumns request", reqs[1])
+	}
+
+	if reqs[2].AppendDimension == nil || reqs[2].AppendDimension.Dimension != "ROWS" {
+		t.Errorf("Requests()[2] = %+v, want the ExpandRows request", reqs[2])
+	}
+
+	for i, want := range []int64{0, 1} {
+		uc := reqs[3+i].UpdateCells
+		if uc == nil || uc.Start.RowIndex != want {
+			t.Errorf("Requests()[%d] = %+v, want the SetCellValue request for row %d", 3+i, reqs[3+i], want)
+		}
+	}
+
+	again, err := b.Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(again, reqs) {
+		t.Error("Requests() changed between calls without new requests")
+	}
+}
+
+func TestStreamingStopsBufferingAfterError(t *testing.T) {
+	b := NewBuilder()
+	b.executor = NewBatchUpdateExecutor(nil, "test", 2)
+	b.streamCtx = context.Background()
+
+	// シート 5 を作る前に参照しているので、最初の stream で Requests が失敗する
+	b.Sheet(5).SetCellValue(0, 0, "a")
+	b.AppendRequest(&sheets.Request{AddSheet: &sheets.AddSheetRequest{
+		Properties: &sheets.SheetProperties{SheetId: 5},
+	}})
+
+	if len(b.errs) != 1 {
+		t.Fatalf("len(errs) = %d, want 1", len(b.errs))
+	}
+
+	for i := range 100 {
+		b.Sheet(0).SetCellValue(i, 0, "x")
+	}
+
+	if len(b.requests) != 0 {
+		t.Errorf("len(requests) = %d after a failed stream, want 0", len(b.requests))
+	}
+
+	if len(b.errs) != 1 {
+		t.Errorf("len(errs) = %d, want 1", len(b.errs))
+	}
+
+	if err := b.Flush(context.Background()); err == nil {
+		t.Error("Flush() error = nil, want the stream error")
+	}
+}
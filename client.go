@@ -2,8 +2,14 @@ package haresheet
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
 
@@ -20,9 +26,67 @@ type SheetInfo struct {
 	Title string
 }
 
+// CalcSettings holds the spreadsheet's calculation settings.
+type CalcSettings struct {
+	AutoRecalc           string // "ON_CHANGE", "MINUTE" or "HOUR"
+	Iterative            bool   // iterative calculation is enabled
+	MaxIterations        int
+	ConvergenceThreshold float64
+}
+
+// ReadStatus describes a read call reported to ReadTrace.
+type ReadStatus struct {
+	Operation     string // operation name (e.g. "GetValues", "GetInfo")
+	SpreadsheetID string // spreadsheet id
+}
+
+// ReadTrace holds hooks called around each read API call.
+type ReadTrace struct {
+	//
+	OnGetStart func(ctx context.Context, status *ReadStatus)
+	//
+	OnGetDone func(ctx context.Context, status *ReadStatus, duration time.Duration, err error)
+}
+
 type Client struct {
-	service  *sheets.Service
-	spreadID string
+	service   *sheets.Service
+	drive     *drive.Service // optional, used by SheetClient.GetSheetRevision
+	spreadID  string
+	readTrace *ReadTrace
+}
+
+// NewServiceFromJSON creates a Sheets service from service account credentials JSON.
+// If readOnly is true, only the read-only spreadsheets scope is requested.
+func NewServiceFromJSON(ctx context.Context, credentialsJSON []byte, readOnly bool) (*sheets.Service, error) {
+	scope := sheets.SpreadsheetsScope
+
+	if readOnly {
+		scope = sheets.SpreadsheetsReadonlyScope
+	}
+
+	service, err := sheets.NewService(ctx,
+		option.WithAuthCredentialsJSON(option.ServiceAccount, credentialsJSON),
+		option.WithScopes(scope),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("NewServiceFromJSON: failed to create service: %w", err)
+	}
+
+	return service, nil
+}
+
+// NewDriveServiceFromJSON creates a Drive service for revision checks (see Client.WithDriveService)
+// from the same service account credentials JSON. Only the drive.metadata.readonly scope is requested.
+func NewDriveServiceFromJSON(ctx context.Context, credentialsJSON []byte) (*drive.Service, error) {
+	service, err := drive.NewService(ctx,
+		option.WithAuthCredentialsJSON(option.ServiceAccount, credentialsJSON),
+		option.WithScopes(drive.DriveMetadataReadonlyScope),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("NewDriveServiceFromJSON: failed to create service: %w", err)
+	}
+
+	return service, nil
 }
 
 func NewClient(service *sheets.Service, spreadsheetID string) *Client {
@@ -32,21 +96,114 @@ func NewClient(service *sheets.Service, spreadsheetID string) *Client {
 	}
 }
 
+// WithDriveService sets the Drive service used to read the spreadsheet's revision.
+func (c *Client) WithDriveService(drv *drive.Service) *Client {
+	c.drive = drv
+
+	return c
+}
+
+// WithReadTrace sets hooks that are called around each read API call.
+func (c *Client) WithReadTrace(trace *ReadTrace) *Client {
+	c.readTrace = trace
+
+	return c
+}
+
+// traceRead runs fn, calling the read trace hooks around it.
+func (c *Client) traceRead(ctx context.Context, op string, fn func() error) error {
+	status := &ReadStatus{
+		Operation:     op,
+		SpreadsheetID: c.spreadID,
+	}
+
+	if c.readTrace != nil && c.readTrace.OnGetStart != nil {
+		c.readTrace.OnGetStart(ctx, status)
+	}
+
+	start := time.Now()
+
+	err := fn()
+
+	if c.readTrace != nil && c.readTrace.OnGetDone != nil {
+		c.readTrace.OnGetDone(ctx, status, time.Since(start), err)
+	}
+
+	return err
+}
+
+// getSpreadsheet fetches the spreadsheet restricted to fields, tracing the call as op.
+func (c *Client) getSpreadsheet(ctx context.Context, op string, fields googleapi.Field) (*sheets.Spreadsheet, error) {
+	var resp *sheets.Spreadsheet
+
+	err := c.traceRead(ctx, op, func() error {
+		var err error
+
+		resp, err = c.service.Spreadsheets.Get(c.spreadID).
+			Fields(fields).
+			Context(ctx).
+			Do()
+
+		return err
+	})
+
+	return resp, err
+}
+
 // GetSheetInfoMap retrieves a map of sheet names to their info (ID and Index).
 // It fetches only the necessary properties to ensure high performance.
 func (c *Client) GetSheetInfoMap(ctx context.Context) (map[string]*SheetInfo, error) {
-	resp, err := c.service.Spreadsheets.Get(c.spreadID).
-		Fields("sheets(properties(sheetId,title,index))").
-		Context(ctx).
-		Do()
+	resp, err := c.getSpreadsheet(ctx, "GetSheetInfoMap", "sheets(properties(sheetId,title,index))")
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get spreadsheet info: %w", err)
 	}
 
-	m := make(map[string]*SheetInfo)
+	return buildSheetInfoMap(ctx, resp.Sheets, nil)
+}
+
+// GetSheetInfoMapFor is like GetSheetInfoMap but only populates entries for the given titles.
+// All sheet properties are still fetched because the API cannot filter by title.
+func (c *Client) GetSheetInfoMapFor(ctx context.Context, titles ...string) (map[string]*SheetInfo, error) {
+	resp, err := c.getSpreadsheet(ctx, "GetSheetInfoMapFor", "sheets(properties(sheetId,title,index))")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spreadsheet info: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(titles))
+
+	for _, t := range titles {
+		wanted[t] = true
+	}
+
+	return buildSheetInfoMap(ctx, resp.Sheets, wanted)
+}
+
+// buildSheetInfoMap builds a title-keyed map of sheet info.
+// If wanted is non-nil, only titles contained in it are included.
+func buildSheetInfoMap(ctx context.Context, sheetList []*sheets.Sheet, wanted map[string]bool) (map[string]*SheetInfo, error) {
+	size := len(sheetList)
+
+	if wanted != nil {
+		size = len(wanted)
+	}
+
+	m := make(map[string]*SheetInfo, size)
+
+	for _, sheet := range sheetList {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if sheet.Properties == nil {
+			continue
+		}
+
+		if wanted != nil && !wanted[sheet.Properties.Title] {
+			continue
+		}
 
-	for _, sheet := range resp.Sheets {
 		m[sheet.Properties.Title] = &SheetInfo{
 			ID:    sheet.Properties.SheetId,
 			Index: int(sheet.Properties.Index),
@@ -57,12 +214,46 @@ func (c *Client) GetSheetInfoMap(ctx context.Context) (map[string]*SheetInfo, er
 	return m, nil
 }
 
+// getGridProperties fetches the grid properties of every sheet keyed by sheet ID.
+func (c *Client) getGridProperties(ctx context.Context) (map[int64]*sheets.GridProperties, error) {
+	resp, err := c.getSpreadsheet(ctx, "GetGridProperties", "sheets(properties(sheetId,gridProperties))")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch spreadsheet info: %w", err)
+	}
+
+	m := make(map[int64]*sheets.GridProperties, len(resp.Sheets))
+
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties == nil || sheet.Properties.GridProperties == nil {
+			continue
+		}
+
+		m[sheet.Properties.SheetId] = sheet.Properties.GridProperties
+	}
+
+	return m, nil
+}
+
+// GetAllGridSizes returns the grid size of every sheet, keyed by sheet ID, as [rows, cols].
+// It fetches the sheet properties once, unlike calling SheetClient.GetGridSize per sheet.
+func (c *Client) GetAllGridSizes(ctx context.Context) (map[int64][2]int, error) {
+	grids, err := c.getGridProperties(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetAllGridSizes: %w", err)
+	}
+
+	m := make(map[int64][2]int, len(grids))
+
+	for id, gp := range grids {
+		m[id] = [2]int{int(gp.RowCount), int(gp.ColumnCount)}
+	}
+
+	return m, nil
+}
+
 // GetInfo returns metadata about the spreadsheet.
 func (c *Client) GetInfo(ctx context.Context) (*SpreadInfo, error) {
-	resp, err := c.service.Spreadsheets.Get(c.spreadID).
-		Fields("spreadsheetId,properties(title)").
-		Context(ctx).
-		Do()
+	resp, err := c.getSpreadsheet(ctx, "GetInfo", "spreadsheetId,properties(title)")
 	if err != nil {
 		return nil, fmt.Errorf("GetInfo: failed to fetch spreadsheet info: %w", err)
 	}
@@ -77,6 +268,30 @@ func (c *Client) GetInfo(ctx context.Context) (*SpreadInfo, error) {
 	}, nil
 }
 
+// GetCalcSettings returns the spreadsheet's recalculation and iterative calculation settings.
+func (c *Client) GetCalcSettings(ctx context.Context) (*CalcSettings, error) {
+	resp, err := c.getSpreadsheet(ctx, "GetCalcSettings", "properties(autoRecalc,iterativeCalculationSettings)")
+	if err != nil {
+		return nil, fmt.Errorf("GetCalcSettings: failed to fetch spreadsheet info: %w", err)
+	}
+
+	if resp.Properties == nil {
+		return nil, fmt.Errorf("GetCalcSettings: properties are missing")
+	}
+
+	settings := &CalcSettings{
+		AutoRecalc: resp.Properties.AutoRecalc,
+	}
+
+	if ic := resp.Properties.IterativeCalculationSettings; ic != nil {
+		settings.Iterative = true
+		settings.MaxIterations = int(ic.MaxIterations)
+		settings.ConvergenceThreshold = ic.ConvergenceThreshold
+	}
+
+	return settings, nil
+}
+
 // Sheet
 func (c *Client) Sheet(sheetID int64) *SheetClient {
 	sc := &SheetClient{
@@ -125,6 +340,91 @@ func (c *Client) AddSheet(ctx context.Context, title string, index int) (int64,
 	return resp.Replies[0].AddSheet.Properties.SheetId, nil
 }
 
+// batchUpdate sends requests to the spreadsheet through a BatchUpdateExecutor with the default batch size.
+func (c *Client) batchUpdate(ctx context.Context, requests []*sheets.Request) error {
+	return NewBatchUpdateExecutor(c.service, c.spreadID, 100).BatchUpdate(ctx, requests)
+}
+
+// CleanupExpiredProtections deletes protections created by ProtectRangeTemp whose expiry has passed.
+func (c *Client) CleanupExpiredProtections(ctx context.Context) error {
+	resp, err := c.getSpreadsheet(ctx, "CleanupExpiredProtections", "sheets(protectedRanges(protectedRangeId,description))")
+	if err != nil {
+		return fmt.Errorf("CleanupExpiredProtections: failed to fetch spreadsheet info: %w", err)
+	}
+
+	now := time.Now()
+
+	var requests []*sheets.Request
+
+	for _, sheet := range resp.Sheets {
+		for _, pr := range sheet.ProtectedRanges {
+			until, ok := parseTempProtection(pr.Description)
+			if !ok || until.After(now) {
+				continue
+			}
+
+			requests = append(requests, &sheets.Request{
+				DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{
+					ProtectedRangeId: pr.ProtectedRangeId,
+				},
+			})
+		}
+	}
+
+	if err := c.batchUpdate(ctx, requests); err != nil {
+		return fmt.Errorf("CleanupExpiredProtections: %w", err)
+	}
+
+	return nil
+}
+
+// GetNamedRanges returns all named ranges of the spreadsheet.
+func (c *Client) GetNamedRanges(ctx context.Context) ([]*sheets.NamedRange, error) {
+	resp, err := c.getSpreadsheet(ctx, "GetNamedRanges", "namedRanges")
+	if err != nil {
+		return nil, fmt.Errorf("GetNamedRanges: failed to fetch spreadsheet info: %w", err)
+	}
+
+	return resp.NamedRanges, nil
+}
+
+// DeleteNamedRangesByPrefix deletes every named range whose name starts with prefix.
+// On failure the error lists the targeted names. Running it again is safe.
+func (c *Client) DeleteNamedRangesByPrefix(ctx context.Context, prefix string) error {
+	if prefix == "" {
+		return errors.New("DeleteNamedRangesByPrefix: prefix should not be empty")
+	}
+
+	ranges, err := c.GetNamedRanges(ctx)
+	if err != nil {
+		return fmt.Errorf("DeleteNamedRangesByPrefix: %w", err)
+	}
+
+	var names []string
+
+	var requests []*sheets.Request
+
+	for _, nr := range ranges {
+		if !strings.HasPrefix(nr.Name, prefix) {
+			continue
+		}
+
+		names = append(names, nr.Name)
+
+		requests = append(requests, &sheets.Request{
+			DeleteNamedRange: &sheets.DeleteNamedRangeRequest{
+				NamedRangeId: nr.NamedRangeId,
+			},
+		})
+	}
+
+	if err := c.batchUpdate(ctx, requests); err != nil {
+		return fmt.Errorf("DeleteNamedRangesByPrefix: failed to delete %v: %w", names, err)
+	}
+
+	return nil
+}
+
 // Builder creates a new Builder instance ready to execute against this client's spreadsheet.
 func (c *Client) Builder() *Builder {
 	b := NewBuilder()
@@ -133,3 +433,27 @@ func (c *Client) Builder() *Builder {
 
 	return b
 }
+
+// StreamingBuilder creates a Builder that sends its requests whenever limit requests are pending,
+// keeping memory bounded for very large jobs. Call Flush to send the remainder.
+// If limit <= 0, the default limit is used.
+//
+// Unlike Builder, it takes ctx: intermediate flushes run inside AppendRequest and the builder
+// methods calling it, none of which take a context, so ctx is kept for the builder's lifetime
+// and cancelling it stops the remaining intermediate flushes.
+//
+// Requests already sent cannot be reordered or taken back, so after an intermediate flush
+// PrependRequest (and methods using it, such as ExpandRows) and restoring an earlier Snapshot
+// record errors. Once any error has been recorded, later requests are dropped rather than
+// buffered, and Flush returns the errors.
+func (c *Client) StreamingBuilder(ctx context.Context, limit int) *Builder {
+	b := c.Builder()
+
+	if limit > 0 {
+		b.WithLimit(limit)
+	}
+
+	b.streamCtx = ctx
+
+	return b
+}
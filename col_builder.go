@@ -50,3 +50,10 @@ func (cb *ColumnBuilder) SetWidth(pixels int) *ColumnBuilder {
 
 	return cb
 }
+
+// AutoResize resizes the columns to fit their content.
+func (cb *ColumnBuilder) AutoResize() *ColumnBuilder {
+	cb.sb.autoResizeDimension("COLUMNS", cb.start, cb.count)
+
+	return cb
+}
@@ -0,0 +1,259 @@
+package haresheet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// SheetReader reads values from a single sheet. SheetClient and FakeSheet implement it.
+type SheetReader interface {
+	GetRangeValues(ctx context.Context, row int, col int, height int, width int) ([][]any, error)
+	GetColValues(ctx context.Context, col, width, skipRows int) ([][]any, error)
+	GetRowValues(ctx context.Context, row, height, skipCols int) ([][]any, error)
+	GetGridSize(ctx context.Context) (rowCount, colCount int, err error)
+}
+
+// SheetWriter sends batch update requests. BatchUpdateExecutor and FakeSheet implement it.
+type SheetWriter interface {
+	BatchUpdate(ctx context.Context, requests []*sheets.Request) error
+}
+
+var (
+	_ SheetReader = (*SheetClient)(nil)
+	_ SheetWriter = (*BatchUpdateExecutor)(nil)
+	_ SheetReader = (*FakeSheet)(nil)
+	_ SheetWriter = (*FakeSheet)(nil)
+)
+
+// FakeSheet is an in-memory sheet for testing code that uses SheetReader and SheetWriter.
+//
+// It records every request passed to BatchUpdate and applies the values written by
+// UpdateCells requests that use Start and whose field mask includes userEnteredValue (or is "*").
+// Sheet IDs are ignored and other requests are only recorded.
+type FakeSheet struct {
+	mu       sync.Mutex
+	grid     [][]any
+	rows     int
+	cols     int
+	requests []*sheets.Request
+}
+
+// NewFakeSheet creates an empty FakeSheet with the given grid size.
+func NewFakeSheet(rows int, cols int) *FakeSheet {
+	if rows < 0 || cols < 0 {
+		panic("fakesheet.NewFakeSheet: negative size")
+	}
+
+	return &FakeSheet{
+		rows: rows,
+		cols: cols,
+	}
+}
+
+// SetValues seeds values starting at (row, col), growing the grid if needed.
+func (f *FakeSheet) SetValues(row int, col int, values [][]any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for r, rowVals := range values {
+		for c, v := range rowVals {
+			f.put(row+r, col+c, v)
+		}
+	}
+}
+
+// Requests returns a copy of the requests recorded by BatchUpdate.
+func (f *FakeSheet) Requests() []*sheets.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make([]*sheets.Request, len(f.requests))
+
+	copy(out, f.requests)
+
+	return out
+}
+
+// BatchUpdate records requests and applies UpdateCells values to the grid.
+func (f *FakeSheet) BatchUpdate(ctx context.Context, requests []*sheets.Request) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, requests...)
+
+	for _, req := range requests {
+		if req == nil || req.UpdateCells == nil || req.UpdateCells.Start == nil {
+			continue
+		}
+
+		// note や書式だけの更新では値に触れない
+		if !fieldsIncludeValue(req.UpdateCells.Fields) {
+			continue
+		}
+
+		start := req.UpdateCells.Start
+
+		for r, rowData := range req.UpdateCells.Rows {
+			if rowData == nil {
+				continue
+			}
+
+			for c, cell := range rowData.Values {
+				f.put(int(start.RowIndex)+r, int(start.ColumnIndex)+c, extendedValue(cell))
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetRangeValues retrieves values like SheetClient.GetRangeValues.
+func (f *FakeSheet) GetRangeValues(ctx context.Context, row int, col int, height int, width int) ([][]any, error) {
+	if row < 0 || col < 0 {
+		return nil, fmt.Errorf("GetRangeValues: invalid row/col: %d, %d", row, col)
+	}
+
+	return f.read(ctx, row, col, height, width)
+}
+
+// GetColValues retrieves values like SheetClient.GetColValues.
+func (f *FakeSheet) GetColValues(ctx context.Context, col, width, skipRows int) ([][]any, error) {
+	if col < 0 || width < 1 || skipRows < 0 {
+		return nil, fmt.Errorf("GetColValues: invalid arguments: %d, %d, %d", col, width, skipRows)
+	}
+
+	return f.read(ctx, skipRows, col, rangeUnset, width)
+}
+
+// GetRowValues retrieves values like SheetClient.GetRowValues.
+func (f *FakeSheet) GetRowValues(ctx context.Context, row, height, skipCols int) ([][]any, error) {
+	if row < 0 || height < 1 || skipCols < 0 {
+		return nil, fmt.Errorf("GetRowValues: invalid arguments: %d, %d, %d", row, height, skipCols)
+	}
+
+	return f.read(ctx, row, skipCols, height, rangeUnset)
+}
+
+// GetGridSize returns the grid size of the fake sheet.
+func (f *FakeSheet) GetGridSize(ctx context.Context) (rowCount, colCount int, err error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rows, f.cols, nil
+}
+
+// read mimics the API: trailing empty rows and cells are omitted, then the result is shaped.
+func (f *FakeSheet) read(ctx context.Context, row int, col int, height int, width int) ([][]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	endRow := len(f.grid)
+
+	if height > 0 {
+		endRow = min(endRow, row+height)
+	}
+
+	var raw [][]any
+
+	for r := row; r < endRow; r++ {
+		src := f.grid[r]
+		endCol := len(src)
+
+		if width > 0 {
+			endCol = min(endCol, col+width)
+		}
+
+		var vals []any
+
+		for c := col; c < endCol; c++ {
+			if src[c] == nil {
+				vals = append(vals, "")
+			} else {
+				vals = append(vals, src[c])
+			}
+		}
+
+		for len(vals) > 0 && isEmptyCell(vals[len(vals)-1]) {
+			vals = vals[:len(vals)-1]
+		}
+
+		raw = append(raw, vals)
+	}
+
+	for len(raw) > 0 && len(raw[len(raw)-1]) == 0 {
+		raw = raw[:len(raw)-1]
+	}
+
+	if raw == nil {
+		raw = [][]any{}
+	}
+
+	return shapeValues(raw, height, width), nil
+}
+
+// put stores v at (row, col), growing the grid. The caller must hold f.mu.
+func (f *FakeSheet) put(row int, col int, v any) {
+	for len(f.grid) <= row {
+		f.grid = append(f.grid, nil)
+	}
+
+	for len(f.grid[row]) <= col {
+		f.grid[row] = append(f.grid[row], nil)
+	}
+
+	f.grid[row][col] = v
+
+	f.rows = max(f.rows, row+1)
+	f.cols = max(f.cols, col+1)
+}
+
+// fieldsIncludeValue reports whether an UpdateCells field mask writes userEnteredValue.
+func fieldsIncludeValue(fields string) bool {
+	for _, f := range strings.Split(fields, ",") {
+		f = strings.TrimSpace(f)
+
+		if f == "*" || f == "userEnteredValue" || strings.HasPrefix(f, "userEnteredValue.") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extendedValue returns the Go value held by a cell's userEnteredValue, or nil if empty.
+func extendedValue(cell *sheets.CellData) any {
+	if cell == nil || cell.UserEnteredValue == nil {
+		return nil
+	}
+
+	ev := cell.UserEnteredValue
+
+	switch {
+	case ev.FormulaValue != nil:
+		return *ev.FormulaValue
+	case ev.StringValue != nil:
+		return *ev.StringValue
+	case ev.NumberValue != nil:
+		return *ev.NumberValue
+	case ev.BoolValue != nil:
+		return *ev.BoolValue
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package haresheet
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestFakeSheetNoteKeepsValue(t *testing.T) {
+	ctx := context.Background()
+
+	fake := NewFakeSheet(1, 1)
+	fake.SetValues(0, 0, [][]any{{"keep"}})
+
+	reqs, err := NewBuilder().Sheet(0).SetNote(0, 0, "a note").Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	if err := fake.BatchUpdate(ctx, reqs); err != nil {
+		t.Fatalf("BatchUpdate() error = %v", err)
+	}
+
+	got, err := fake.GetRangeValues(ctx, 0, 0, 1, 1)
+	if err != nil {
+		t.Fatalf("GetRangeValues() error = %v", err)
+	}
+
+	if want := [][]any{{"keep"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRangeValues() = %v, want %v", got, want)
+	}
+
+	if n := len(fake.Requests()); n != 1 {
+		t.Errorf("len(Requests()) = %d, want 1", n)
+	}
+}
+
+func TestFakeSheetAppliesValues(t *testing.T) {
+	ctx := context.Background()
+
+	fake := NewFakeSheet(0, 0)
+
+	reqs, err := NewBuilder().Sheet(0).SetRangeValues(0, 0, [][]any{{"a", 1.0}}).Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	if err := fake.BatchUpdate(ctx, reqs); err != nil {
+		t.Fatalf("BatchUpdate() error = %v", err)
+	}
+
+	got, err := fake.GetRangeValues(ctx, 0, 0, 1, 2)
+	if err != nil {
+		t.Fatalf("GetRangeValues() error = %v", err)
+	}
+
+	if want := [][]any{{"a", 1.0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRangeValues() = %v, want %v", got, want)
+	}
+}
+
+func TestFieldsIncludeValue(t *testing.T) {
+	tests := map[string]bool{
+		"userEnteredValue":                  true,
+		"*":                                 true,
+		"note, userEnteredValue":            true,
+		"userEnteredValue.stringValue":      true,
+		"note":                              false,
+		"textFormatRuns":                    false,
+		"userEnteredFormat.backgroundColor": false,
+		"":                                  false,
+	}
+
+	for fields, want := range tests {
+		if got := fieldsIncludeValue(fields); got != want {
+			t.Errorf("fieldsIncludeValue(%q) = %v, want %v", fields, got, want)
+		}
+	}
+}
@@ -95,6 +95,23 @@ func (or *OffsetRow[T]) Offset() int {
 	return or.offset
 }
 
+// Clone returns a copy of the row preserving its offset and capacity.
+func (or *OffsetRow[T]) Clone() *OffsetRow[T] {
+	return &OffsetRow[T]{
+		r:      or.r.Clone(),
+		offset: or.offset,
+	}
+}
+
+// Equal reports whether or and other have the same offset and equal values.
+func (or *OffsetRow[T]) Equal(other *OffsetRow[T], eq func(a, b T) bool) bool {
+	if other == nil || or.offset != other.offset {
+		return false
+	}
+
+	return or.r.Equal(other.r, eq)
+}
+
 // --------------------------------------------------------------------------------
 // safe passthroughs (no index arguments)
 // --------------------------------------------------------------------------------
@@ -0,0 +1,154 @@
+package haresheet
+
+import (
+	"context"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// RelativeBuilder wraps a SheetBuilder so that coordinates are relative to a base cell,
+// which is handy when filling a template region. The base is added to every row, column
+// and rect before delegating, and the result is validated by the SheetBuilder as usual.
+type RelativeBuilder struct {
+	sb      *SheetBuilder
+	baseRow int
+	baseCol int
+}
+
+// Requests
+func (rb *RelativeBuilder) Requests() ([]*sheets.Request, error) {
+	return rb.sb.b.Requests()
+}
+
+// Flush executes the batched requests.
+func (rb *RelativeBuilder) Flush(ctx context.Context) error {
+	return rb.sb.Flush(ctx)
+}
+
+// Sheet returns the underlying SheetBuilder, which takes absolute coordinates.
+func (rb *RelativeBuilder) Sheet() *SheetBuilder {
+	return rb.sb
+}
+
+// Relative returns a builder nested at (row, col) relative to this builder's base.
+func (rb *RelativeBuilder) Relative(row int, col int) *RelativeBuilder {
+	return rb.sb.Relative(rb.baseRow+row, rb.baseCol+col)
+}
+
+// rect returns a copy of rect shifted by the base. A nil rect is returned as is for the callee to reject.
+func (rb *RelativeBuilder) rect(rect *Rect) *Rect {
+	if rect == nil {
+		return nil
+	}
+
+	shifted := *rect
+	shifted.Row += rb.baseRow
+	shifted.Col += rb.baseCol
+
+	return &shifted
+}
+
+// SetCellValue
+func (rb *RelativeBuilder) SetCellValue(row int, col int, value any) *RelativeBuilder {
+	rb.sb.SetCellValue(rb.baseRow+row, rb.baseCol+col, value)
+
+	return rb
+}
+
+// SetTextCell
+func (rb *RelativeBuilder) SetTextCell(row int, col int, value string) *RelativeBuilder {
+	rb.sb.SetTextCell(rb.baseRow+row, rb.baseCol+col, value)
+
+	return rb
+}
+
+// SetCellFormula
+func (rb *RelativeBuilder) SetCellFormula(row int, col int, formula string) *RelativeBuilder {
+	rb.sb.SetCellFormula(rb.baseRow+row, rb.baseCol+col, formula)
+
+	return rb
+}
+
+// SetCellText
+func (rb *RelativeBuilder) SetCellText(row int, col int, text string) *RelativeBuilder {
+	rb.sb.SetCellText(rb.baseRow+row, rb.baseCol+col, text)
+
+	return rb
+}
+
+// SetRowValues
+func (rb *RelativeBuilder) SetRowValues(row int, col int, values []any) *RelativeBuilder {
+	rb.sb.SetRowValues(rb.baseRow+row, rb.baseCol+col, values)
+
+	return rb
+}
+
+// SetColValues
+func (rb *RelativeBuilder) SetColValues(row int, col int, values []any) *RelativeBuilder {
+	rb.sb.SetColValues(rb.baseRow+row, rb.baseCol+col, values)
+
+	return rb
+}
+
+// SetRangeValues
+func (rb *RelativeBuilder) SetRangeValues(row int, col int, values [][]any) *RelativeBuilder {
+	rb.sb.SetRangeValues(rb.baseRow+row, rb.baseCol+col, values)
+
+	return rb
+}
+
+// SetNote
+func (rb *RelativeBuilder) SetNote(row int, col int, note string) *RelativeBuilder {
+	rb.sb.SetNote(rb.baseRow+row, rb.baseCol+col, note)
+
+	return rb
+}
+
+// Merge
+func (rb *RelativeBuilder) Merge(rect *Rect, mergeType MergeType) *RelativeBuilder {
+	rb.sb.Merge(rb.rect(rect), mergeType)
+
+	return rb
+}
+
+// SetBackgroundColor
+func (rb *RelativeBuilder) SetBackgroundColor(rect *Rect, color *sheets.Color) *RelativeBuilder {
+	rb.sb.SetBackgroundColor(rb.rect(rect), color)
+
+	return rb
+}
+
+// SetForegroundColor
+func (rb *RelativeBuilder) SetForegroundColor(rect *Rect, color *sheets.Color) *RelativeBuilder {
+	rb.sb.SetForegroundColor(rb.rect(rect), color)
+
+	return rb
+}
+
+// SetTextStyle
+func (rb *RelativeBuilder) SetTextStyle(rect *Rect, style TextStyle) *RelativeBuilder {
+	rb.sb.SetTextStyle(rb.rect(rect), style)
+
+	return rb
+}
+
+// SetNumberFormat
+func (rb *RelativeBuilder) SetNumberFormat(rect *Rect, formatType NumberFormatType, pattern string) *RelativeBuilder {
+	rb.sb.SetNumberFormat(rb.rect(rect), formatType, pattern)
+
+	return rb
+}
+
+// SetBorders
+func (rb *RelativeBuilder) SetBorders(rect *Rect, borders *Borders) *RelativeBuilder {
+	rb.sb.SetBorders(rb.rect(rect), borders)
+
+	return rb
+}
+
+// ClearRangeValues
+func (rb *RelativeBuilder) ClearRangeValues(rect *Rect) *RelativeBuilder {
+	rb.sb.ClearRangeValues(rb.rect(rect))
+
+	return rb
+}
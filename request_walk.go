@@ -0,0 +1,234 @@
+package haresheet
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+var (
+	gridRangeType      = reflect.TypeOf(&sheets.GridRange{})
+	dimensionRangeType = reflect.TypeOf(&sheets.DimensionRange{})
+	updateCellsType    = reflect.TypeOf(&sheets.UpdateCellsRequest{})
+
+	// cell payloads never contain ranges, so they are not walked
+	walkSkippedTypes = map[reflect.Type]bool{
+		reflect.TypeOf(&sheets.RowData{}):  true,
+		reflect.TypeOf(&sheets.CellData{}): true,
+	}
+)
+
+// walkRequestRanges calls fn with the A1 description of every range referenced by req.
+// GridRanges, DimensionRanges and the area written by UpdateCells are reported.
+func walkRequestRanges(req *sheets.Request, fn func(a1 string)) {
+	walkRanges(reflect.ValueOf(req), fn)
+}
+
+func walkRanges(v reflect.Value, fn func(a1 string)) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || walkSkippedTypes[v.Type()] {
+			return
+		}
+
+		switch v.Type() {
+		case gridRangeType:
+			fn(GridRangeToA1(v.Interface().(*sheets.GridRange)))
+
+			return
+		case dimensionRangeType:
+			fn(dimensionRangeToA1(v.Interface().(*sheets.DimensionRange)))
+
+			return
+		case updateCellsType:
+			if a1, ok := updateCellsToA1(v.Interface().(*sheets.UpdateCellsRequest)); ok {
+				fn(a1)
+			}
+		}
+
+		walkRanges(v.Elem(), fn)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				walkRanges(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			walkRanges(v.Index(i), fn)
+		}
+	}
+}
+
+// GridRangeToA1 renders a GridRange as "sheetId=<id>!<A1>" for display purposes.
+// Open ends are rendered as whole rows ("2:5") or columns ("A:C") where possible;
+// a range covering the whole sheet is rendered as "sheetId=<id>".
+func GridRangeToA1(rng *sheets.GridRange) string {
+	if rng == nil {
+		return ""
+	}
+
+	prefix := "sheetId=" + strconv.FormatInt(rng.SheetId, 10)
+
+	a1 := spanToA1(rng.StartRowIndex, rng.EndRowIndex, rng.StartColumnIndex, rng.EndColumnIndex)
+	if a1 == "" {
+		return prefix
+	}
+
+	return prefix + "!" + a1
+}
+
+// spanToA1 renders 0-based half-open row and column spans in A1 notation.
+// An end of 0 means unbounded. A range open in both directions renders as its
+// start cell followed by ":" (e.g. "A3:"), or "" for the whole sheet.
+func spanToA1(startRow, endRow, startCol, endCol int64) string {
+	rowsOpen := endRow == 0
+	colsOpen := endCol == 0
+
+	switch {
+	case rowsOpen && colsOpen && startRow == 0 && startCol == 0:
+		return ""
+	case rowsOpen && colsOpen:
+		return string(ColIndexToLetters(int(startCol))) + strconv.FormatInt(startRow+1, 10) + ":"
+	case colsOpen && startCol == 0:
+		return strconv.FormatInt(startRow+1, 10) + ":" + strconv.FormatInt(endRow, 10)
+	case rowsOpen && startRow == 0:
+		return string(ColIndexToLetters(int(startCol))) + ":" + string(ColIndexToLetters(int(endCol-1)))
+	}
+
+	b := make([]byte, 0, 16)
+	b = append(b, ColIndexToLetters(int(startCol))...)
+	b = strconv.AppendInt(b, startRow+1, 10)
+	b = append(b, ':')
+
+	if !colsOpen {
+		b = append(b, ColIndexToLetters(int(endCol-1))...)
+	}
+
+	if !rowsOpen {
+		b = strconv.AppendInt(b, endRow, 10)
+	}
+
+	return string(b)
+}
+
+// dimensionRangeToA1 renders a DimensionRange as whole rows or columns.
+func dimensionRangeToA1(rng *sheets.DimensionRange) string {
+	if rng.Dimension == "COLUMNS" {
+		return GridRangeToA1(&sheets.GridRange{
+			SheetId:          rng.SheetId,
+			StartColumnIndex: rng.StartIndex,
+			EndColumnIndex:   rng.EndIndex,
+		})
+	}
+
+	return GridRangeToA1(&sheets.GridRange{
+		SheetId:       rng.SheetId,
+		StartRowIndex: rng.StartIndex,
+		EndRowIndex:   rng.EndIndex,
+	})
+}
+
+// updateCellsToA1 renders the area written by an UpdateCells request that uses Start.
+func updateCellsToA1(req *sheets.UpdateCellsRequest) (string, bool) {
+	if req.Start == nil || len(req.Rows) == 0 {
+		return "", false
+	}
+
+	width := 0
+
+	for _, row := range req.Rows {
+		if row != nil {
+			width = max(width, len(row.Values))
+		}
+	}
+
+	if width == 0 {
+		return "", false
+	}
+
+	return GridRangeToA1(&sheets.GridRange{
+		SheetId:          req.Start.SheetId,
+		StartRowIndex:    req.Start.RowIndex,
+		EndRowIndex:      req.Start.RowIndex + int64(len(req.Rows)),
+		StartColumnIndex: req.Start.ColumnIndex,
+		EndColumnIndex:   req.Start.ColumnIndex + int64(width),
+	}), true
+}
+
+// walkSheetIDs calls fn with every sheet ID referenced by req (SheetId and SourceSheetId fields).
+func walkSheetIDs(req *sheets.Request, fn func(id int64)) {
+	walkIDs(reflect.ValueOf(req), fn)
+}
+
+func walkIDs(v reflect.Value, fn func(id int64)) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || walkSkippedTypes[v.Type()] {
+			return
+		}
+
+		walkIDs(v.Elem(), fn)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+
+			if !f.IsExported() {
+				continue
+			}
+
+			if (f.Name == "SheetId" || f.Name == "SourceSheetId") && f.Type.Kind() == reflect.Int64 {
+				fn(v.Field(i).Int())
+
+				continue
+			}
+
+			walkIDs(v.Field(i), fn)
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			walkIDs(v.Index(i), fn)
+		}
+	}
+}
+
+// checkSheetCreationOrder returns an error if a request references a sheet
+// that is created (by AddSheet or DuplicateSheet with a new ID) later in requests.
+func checkSheetCreationOrder(requests []*sheets.Request) error {
+	createdAt := make(map[int64]int)
+
+	for i, req := range requests {
+		var id int64
+
+		switch {
+		case req.AddSheet != nil && req.AddSheet.Properties != nil:
+			id = req.AddSheet.Properties.SheetId
+		case req.DuplicateSheet != nil:
+			id = req.DuplicateSheet.NewSheetId
+		}
+
+		// 0 は未指定 (サーバー側で採番) と区別できないので対象外
+		if _, ok := createdAt[id]; id != 0 && !ok {
+			createdAt[id] = i
+		}
+	}
+
+	if len(createdAt) == 0 {
+		return nil
+	}
+
+	var errs []error
+
+	for i, req := range requests {
+		walkSheetIDs(req, func(id int64) {
+			if at, ok := createdAt[id]; ok && at > i {
+				errs = append(errs, fmt.Errorf("Requests: request %d references sheet %d before it is created by request %d", i, id, at))
+			}
+		})
+	}
+
+	return errors.Join(errs...)
+}
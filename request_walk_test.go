@@ -0,0 +1,52 @@
+package haresheet
+
+import "testing"
+
+func TestSpanToA1(t *testing.T) {
+	tests := []struct {
+		name                               string
+		startRow, endRow, startCol, endCol int64
+		want                               string
+	}{
+		// 行・列とも閉じている
+		{"closed", 2, 5, 2, 5, "C3:E5"},
+		{"closed from origin", 0, 1, 0, 1, "A1:A1"},
+
+		// 列だけ開いている
+		{"cols open from A", 2, 5, 0, 0, "3:5"},
+		{"cols open from C", 2, 5, 2, 0, "C3:5"},
+
+		// 行だけ開いている
+		{"rows open from 1", 0, 0, 2, 5, "C:E"},
+		{"rows open from 3", 2, 0, 2, 5, "C3:E"},
+
+		// 行・列とも開いている
+		{"whole sheet", 0, 0, 0, 0, ""},
+		{"both open from A3", 2, 0, 0, 0, "A3:"},
+		{"both open from C1", 0, 0, 2, 0, "C1:"},
+		{"both open from C3", 2, 0, 2, 0, "C3:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spanToA1(tt.startRow, tt.endRow, tt.startCol, tt.endCol); got != tt.want {
+				t.Errorf("spanToA1(%d, %d, %d, %d) = %q, want %q", tt.startRow, tt.endRow, tt.startCol, tt.endCol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeSummaryOpenRows(t *testing.T) {
+	b := NewBuilder()
+
+	b.Sheet(1).SetBackgroundColor(&Rect{Row: 2, Col: 0, Height: rangeUnset, Width: rangeUnset}, MustParseHexColor("#FF0000"))
+
+	got, err := b.RangeSummary()
+	if err != nil {
+		t.Fatalf("RangeSummary() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0] != "sheetId=1!A3:" {
+		t.Errorf("RangeSummary() = %q, want [\"sheetId=1!A3:\"]", got)
+	}
+}
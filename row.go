@@ -165,3 +165,51 @@ func (r *Row[T]) Values() []T {
 
 	return out
 }
+
+// ForEach calls fn for each value in the row with its index.
+func (r *Row[T]) ForEach(fn func(i int, v T)) {
+	for i, v := range r.s {
+		fn(i, v)
+	}
+}
+
+// MapRow returns a new Row holding fn applied to each value of r.
+func MapRow[T, U any](r *Row[T], fn func(T) U) *Row[U] {
+	out := &Row[U]{
+		s: make([]U, len(r.s)),
+	}
+
+	for i, v := range r.s {
+		out.s[i] = fn(v)
+	}
+
+	return out
+}
+
+// Clone returns a copy of the row with the same length and capacity.
+func (r *Row[T]) Clone() *Row[T] {
+	out := &Row[T]{}
+
+	if r.s != nil {
+		out.s = make([]T, len(r.s), cap(r.s))
+
+		copy(out.s, r.s)
+	}
+
+	return out
+}
+
+// Equal reports whether r and other have the same length and eq holds for every pair of values.
+func (r *Row[T]) Equal(other *Row[T], eq func(a, b T) bool) bool {
+	if other == nil || len(r.s) != len(other.s) {
+		return false
+	}
+
+	for i := range r.s {
+		if !eq(r.s[i], other.s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -50,3 +50,10 @@ func (rb *RowBuilder) SetHeight(pixels int) *RowBuilder {
 
 	return rb
 }
+
+// AutoResize resizes the rows to fit their content.
+func (rb *RowBuilder) AutoResize() *RowBuilder {
+	rb.sb.autoResizeDimension("ROWS", rb.start, rb.count)
+
+	return rb
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/api/sheets/v4"
 )
@@ -25,6 +27,53 @@ func (sb *SheetBuilder) Flush(ctx context.Context) error {
 	return sb.b.Flush(ctx)
 }
 
+// AppendRequest appends a hand-built request to the underlying builder.
+// A nil request is ignored.
+func (sb *SheetBuilder) AppendRequest(request *sheets.Request) *SheetBuilder {
+	sb.b.AppendRequest(request)
+
+	return sb
+}
+
+// Try runs fn with this builder and records any error it returns, like the builder's own methods do.
+// It lets custom logic that can fail take part in a fluent chain.
+func (sb *SheetBuilder) Try(fn func(*SheetBuilder) error) *SheetBuilder {
+	if fn == nil {
+		sb.b.appendError(errors.New("Try: fn should not be nil"))
+
+		return sb
+	}
+
+	if err := fn(sb); err != nil {
+		sb.b.appendError(fmt.Errorf("Try: %w", err))
+	}
+
+	return sb
+}
+
+// Relative returns a builder whose methods take coordinates relative to (baseRow, baseCol).
+func (sb *SheetBuilder) Relative(baseRow int, baseCol int) *RelativeBuilder {
+	rb := &RelativeBuilder{
+		sb:      sb,
+		baseRow: baseRow,
+		baseCol: baseCol,
+	}
+
+	if baseRow < 0 {
+		sb.b.appendError(fmt.Errorf("Relative: invalid base row: %d", baseRow))
+
+		return rb
+	}
+
+	if baseCol < 0 {
+		sb.b.appendError(fmt.Errorf("Relative: invalid base col: %d", baseCol))
+
+		return rb
+	}
+
+	return rb
+}
+
 // Row returns a builder object for row operations.
 func (sb *SheetBuilder) Row(startRow int, count int) *RowBuilder {
 	rb := &RowBuilder{
@@ -95,25 +144,12 @@ func (sb *SheetBuilder) CopyRange(srcSheetID int64, src *Rect, dstR int, dstC in
 		return sb
 	}
 
-	if pasteType == "" {
-		pasteType = PasteTypeNormal
-	}
+	pasteType = sb.b.pasteTypeOr(pasteType)
 
-	srcRange := &sheets.GridRange{
-		SheetId:          srcSheetID,
-		StartRowIndex:    int64(src.Row),
-		EndRowIndex:      int64(src.Row + src.Height),
-		StartColumnIndex: int64(src.Col),
-		EndColumnIndex:   int64(src.Col + src.Width),
-	}
+	srcRange := sb.gridRange(src)
+	srcRange.SheetId = srcSheetID
 
-	dstRange := &sheets.GridRange{
-		SheetId:          sb.sheetID,
-		StartRowIndex:    int64(dstR),
-		EndRowIndex:      int64(dstR + src.Height),
-		StartColumnIndex: int64(dstC),
-		EndColumnIndex:   int64(dstC + src.Width),
-	}
+	dstRange := sb.gridRange(&Rect{Row: dstR, Col: dstC, Height: src.Height, Width: src.Width})
 
 	req := &sheets.Request{
 		CopyPaste: &sheets.CopyPasteRequest{
@@ -177,9 +213,7 @@ func (sb *SheetBuilder) FillDownFrom(srcSheetID int64, src *Rect, dstR int, dstC
 		EndColumnIndex:   int64(dstC + src.Width),
 	}
 
-	if pasteType == "" {
-		pasteType = PasteTypeNormal
-	}
+	pasteType = sb.b.pasteTypeOr(pasteType)
 
 	req := &sheets.Request{
 		CopyPaste: &sheets.CopyPasteRequest{
@@ -195,6 +229,39 @@ func (sb *SheetBuilder) FillDownFrom(srcSheetID int64, src *Rect, dstR int, dstC
 	return sb
 }
 
+// ShiftRange moves the contents of rect by rowDelta rows and colDelta columns (cut and paste).
+// The source cells are cleared and the destination is overwritten.
+func (sb *SheetBuilder) ShiftRange(rect *Rect, rowDelta int, colDelta int) *SheetBuilder {
+	if sb.isRectInvalid(rect, "ShiftRange", "rect") {
+		return sb
+	}
+
+	dstR := rect.Row + rowDelta
+	dstC := rect.Col + colDelta
+
+	if dstR < 0 || dstC < 0 {
+		sb.b.appendError(fmt.Errorf("ShiftRange: destination out of sheet: row %d, col %d", dstR, dstC))
+
+		return sb
+	}
+
+	req := &sheets.Request{
+		CutPaste: &sheets.CutPasteRequest{
+			Source: sb.gridRange(rect),
+			Destination: &sheets.GridCoordinate{
+				SheetId:     sb.sheetID,
+				RowIndex:    int64(dstR),
+				ColumnIndex: int64(dstC),
+			},
+			PasteType: string(PasteTypeNormal),
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
 // Merge
 func (sb *SheetBuilder) Merge(rect *Rect, mergeType MergeType) *SheetBuilder {
 	if rect == nil {
@@ -209,13 +276,7 @@ func (sb *SheetBuilder) Merge(rect *Rect, mergeType MergeType) *SheetBuilder {
 
 	req := &sheets.Request{
 		MergeCells: &sheets.MergeCellsRequest{
-			Range: &sheets.GridRange{
-				SheetId:          sb.sheetID,
-				StartRowIndex:    int64(rect.Row),
-				EndRowIndex:      int64(rect.Row + rect.Height),
-				StartColumnIndex: int64(rect.Col),
-				EndColumnIndex:   int64(rect.Col + rect.Width),
-			},
+			Range:     sb.gridRange(rect),
 			MergeType: string(mergeType),
 		},
 	}
@@ -235,6 +296,40 @@ func (sb *SheetBuilder) MergeCols(rect *Rect) *SheetBuilder {
 	return sb.Merge(rect, MergeTypeCols)
 }
 
+// MergeEntireRow merges the cells of row from column skipCols to the end of the sheet.
+func (sb *SheetBuilder) MergeEntireRow(row int, skipCols int) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("MergeEntireRow: invalid row: %d", row))
+
+		return sb
+	}
+
+	if skipCols < 0 {
+		sb.b.appendError(fmt.Errorf("MergeEntireRow: invalid skipCols: %d", skipCols))
+
+		return sb
+	}
+
+	return sb.Merge(&Rect{Row: row, Col: skipCols, Height: 1, Width: rangeUnset}, MergeTypeAll)
+}
+
+// MergeEntireColumn merges the cells of col from row skipRows to the end of the sheet.
+func (sb *SheetBuilder) MergeEntireColumn(col int, skipRows int) *SheetBuilder {
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("MergeEntireColumn: invalid col: %d", col))
+
+		return sb
+	}
+
+	if skipRows < 0 {
+		sb.b.appendError(fmt.Errorf("MergeEntireColumn: invalid skipRows: %d", skipRows))
+
+		return sb
+	}
+
+	return sb.Merge(&Rect{Row: skipRows, Col: col, Height: rangeUnset, Width: 1}, MergeTypeAll)
+}
+
 // ExpandRows
 func (sb *SheetBuilder) ExpandRows(count int) *SheetBuilder {
 	if count < 0 {
@@ -329,7 +424,7 @@ func (sb *SheetBuilder) SetCellValue(row int, col int, value any) *SheetBuilder
 		return sb
 	}
 
-	cell := sb.toCellData(value)
+	cell := sb.toCellData(value, "SetCellValue")
 
 	req := &sheets.Request{
 		UpdateCells: &sheets.UpdateCellsRequest{
@@ -350,113 +445,117 @@ func (sb *SheetBuilder) SetCellValue(row int, col int, value any) *SheetBuilder
 	return sb
 }
 
-// SetRowValues
-func (sb *SheetBuilder) SetRowValues(row int, col int, values []any) *SheetBuilder {
+// SetTextCell writes value as plain text and applies a TEXT number format in the same request,
+// so values such as zip codes or IDs ("01234") are displayed verbatim.
+func (sb *SheetBuilder) SetTextCell(row int, col int, value string) *SheetBuilder {
 	if row < 0 {
-		sb.b.appendError(fmt.Errorf("SetRowValues: invalid row: %d", row))
+		sb.b.appendError(fmt.Errorf("SetTextCell: invalid row: %d", row))
 
 		return sb
 	}
 
 	if col < 0 {
-		sb.b.appendError(fmt.Errorf("SetRowValues: invalid col: %d", col))
+		sb.b.appendError(fmt.Errorf("SetTextCell: invalid col: %d", col))
 
 		return sb
 	}
 
-	if len(values) == 0 {
-		sb.b.appendError(errors.New("SetRowValues: values should not be nil or empty"))
+	cell := &sheets.CellData{
+		UserEnteredValue: &sheets.ExtendedValue{StringValue: &value},
+		UserEnteredFormat: &sheets.CellFormat{
+			NumberFormat: &sheets.NumberFormat{Type: "TEXT"},
+		},
+	}
+
+	return sb.updateCell(row, col, cell, "userEnteredValue,userEnteredFormat.numberFormat")
+}
+
+// SetArrayFormula writes formula (e.g. an ARRAYFORMULA or a spilling formula) into a single cell.
+// The formula is always stored as a formula; a leading "=" is added if missing.
+func (sb *SheetBuilder) SetArrayFormula(row int, col int, formula string) *SheetBuilder {
+	return sb.setFormula("SetArrayFormula", row, col, formula)
+}
+
+// setFormula validates and writes formula into a single cell as a formula.
+func (sb *SheetBuilder) setFormula(label string, row int, col int, formula string) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid row: %d", label, row))
 
 		return sb
 	}
 
-	cells := make([]*sheets.CellData, 0, len(values))
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid col: %d", label, col))
 
-	for _, v := range values {
-		cells = append(cells, sb.toCellData(v))
+		return sb
 	}
 
-	req := &sheets.Request{
-		UpdateCells: &sheets.UpdateCellsRequest{
-			Start: &sheets.GridCoordinate{
-				SheetId:     sb.sheetID,
-				RowIndex:    int64(row),
-				ColumnIndex: int64(col),
-			},
-			Rows: []*sheets.RowData{
-				{Values: cells},
-			},
-			Fields: "userEnteredValue",
-		},
-	}
+	if strings.TrimPrefix(formula, "=") == "" {
+		sb.b.appendError(fmt.Errorf("%s: formula should not be empty", label))
 
-	sb.b.AppendRequest(req)
+		return sb
+	}
 
-	return sb
+	return sb.updateCell(row, col, formulaCell(formula), "userEnteredValue")
 }
 
-// SetColValues sets values vertically starting from the specified cell.
-// It automatically converts a 1D slice into a vertical 2D slice.
-func (sb *SheetBuilder) SetColValues(row int, col int, values []any) *SheetBuilder {
+// SetNote sets the note of a single cell. An empty note clears it.
+func (sb *SheetBuilder) SetNote(row int, col int, note string) *SheetBuilder {
 	if row < 0 {
-		sb.b.appendError(fmt.Errorf("SetColValues: invalid row: %d", row))
+		sb.b.appendError(fmt.Errorf("SetNote: invalid row: %d", row))
 
 		return sb
 	}
 
 	if col < 0 {
-		sb.b.appendError(fmt.Errorf("SetColValues: invalid col: %d", col))
+		sb.b.appendError(fmt.Errorf("SetNote: invalid col: %d", col))
 
 		return sb
 	}
 
-	if len(values) == 0 {
-		sb.b.appendError(errors.New("SetColValues: values should not be nil or empty"))
+	return sb.updateCell(row, col, &sheets.CellData{Note: note}, "note")
+}
 
+// SetRangeNote sets the same note on every cell of rect. An empty note clears them.
+func (sb *SheetBuilder) SetRangeNote(rect *Rect, note string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetRangeNote", "rect") {
 		return sb
 	}
 
-	verticalData := make([][]any, len(values))
-
-	for i, v := range values {
-		verticalData[i] = []any{v}
-	}
-
-	return sb.SetRangeValues(row, col, verticalData)
+	return sb.repeatCell(rect, &sheets.CellData{Note: note}, "note")
 }
 
-// SetRangeValues
-func (sb *SheetBuilder) SetRangeValues(row int, col int, values [][]any) *SheetBuilder {
+// SetNotes writes a matrix of notes starting at (row, col) in a single request.
+// An empty string clears the note of that cell. Values and formats are left unchanged.
+func (sb *SheetBuilder) SetNotes(row int, col int, notes [][]string) *SheetBuilder {
 	if row < 0 {
-		sb.b.appendError(fmt.Errorf("SetRangeValues: invalid row: %d", row))
+		sb.b.appendError(fmt.Errorf("SetNotes: invalid row: %d", row))
 
 		return sb
 	}
 
 	if col < 0 {
-		sb.b.appendError(fmt.Errorf("SetRangeValues: invalid col: %d", col))
+		sb.b.appendError(fmt.Errorf("SetNotes: invalid col: %d", col))
 
 		return sb
 	}
 
-	if len(values) == 0 {
-		sb.b.appendError(errors.New("SetRangeValues: values should not be nil or empty"))
+	if len(notes) == 0 {
+		sb.b.appendError(errors.New("SetNotes: notes should not be nil or empty"))
 
 		return sb
 	}
 
-	rows := make([]*sheets.RowData, 0, len(values))
+	rows := make([]*sheets.RowData, len(notes))
 
-	for _, rowVals := range values {
-		cells := make([]*sheets.CellData, 0, len(rowVals))
+	for i, rowNotes := range notes {
+		cells := make([]*sheets.CellData, len(rowNotes))
 
-		for _, v := range rowVals {
-			cells = append(cells, sb.toCellData(v))
+		for j, note := range rowNotes {
+			cells[j] = &sheets.CellData{Note: note}
 		}
 
-		rows = append(rows, &sheets.RowData{
-			Values: cells,
-		})
+		rows[i] = &sheets.RowData{Values: cells}
 	}
 
 	req := &sheets.Request{
@@ -467,7 +566,7 @@ func (sb *SheetBuilder) SetRangeValues(row int, col int, values [][]any) *SheetB
 				ColumnIndex: int64(col),
 			},
 			Rows:   rows,
-			Fields: "userEnteredValue",
+			Fields: "note",
 		},
 	}
 
@@ -476,113 +575,85 @@ func (sb *SheetBuilder) SetRangeValues(row int, col int, values [][]any) *SheetB
 	return sb
 }
 
-// toCellData
-func (sb *SheetBuilder) toCellData(v any) *sheets.CellData {
-	cd := &sheets.CellData{}
+// SetCellFormula writes formula into a cell as a formula, adding a leading "=" if missing.
+// Unlike SetCellValue, it does not depend on the "=" prefix or the builder's InputMode.
+func (sb *SheetBuilder) SetCellFormula(row int, col int, formula string) *SheetBuilder {
+	return sb.setFormula("SetCellFormula", row, col, formula)
+}
 
-	switch val := v.(type) {
-	case string:
-		// "=" で始まるなら数式として扱う
-		if len(val) > 0 && val[0] == '=' {
-			cd.UserEnteredValue = &sheets.ExtendedValue{FormulaValue: &val}
-		} else {
-			cd.UserEnteredValue = &sheets.ExtendedValue{StringValue: &val}
-		}
-	case int:
-		f := float64(val)
-		cd.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &f}
-	case float64:
-		cd.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &val}
-	case bool:
-		cd.UserEnteredValue = &sheets.ExtendedValue{BoolValue: &val}
+// SetCellText writes text into a cell as a string, even if it starts with "=".
+// The cell's number format is left unchanged; use SetTextCell to also apply a TEXT format.
+func (sb *SheetBuilder) SetCellText(row int, col int, text string) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("SetCellText: invalid row: %d", row))
+
+		return sb
 	}
 
-	return cd
-}
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("SetCellText: invalid col: %d", col))
 
-// ProtectRange protects the specified area.
-// If warningOnly is true, it shows a warning when editing but allows changes.
-// If warningOnly is false, it restricts editing to the specified users (or owner only if users is empty).
-func (sb *SheetBuilder) ProtectRange(rect *Rect, description string, users []string, warningOnly bool) *SheetBuilder {
-	if sb.isRectInvalid(rect, "ProtectRange", "rect") {
 		return sb
 	}
 
-	return sb.addProtectedRangeRequest(description, users, warningOnly, &sheets.GridRange{
-		SheetId:          int64(sb.sheetID),
-		StartRowIndex:    int64(rect.Row),
-		EndRowIndex:      int64(rect.Row + rect.Height),
-		StartColumnIndex: int64(rect.Col),
-		EndColumnIndex:   int64(rect.Col + rect.Width),
-	})
-}
+	cell := &sheets.CellData{
+		UserEnteredValue: &sheets.ExtendedValue{StringValue: &text},
+	}
 
-// ProtectSheet protects the entire sheet.
-func (sb *SheetBuilder) ProtectSheet(description string, users []string, warningOnly bool) *SheetBuilder {
-	return sb.addProtectedRangeRequest(description, users, warningOnly, &sheets.GridRange{
-		SheetId: int64(sb.sheetID),
-	})
+	return sb.updateCell(row, col, cell, "userEnteredValue")
 }
 
-// addProtectedRangeRequest
-func (sb *SheetBuilder) addProtectedRangeRequest(desc string, users []string, warningOnly bool, rng *sheets.GridRange) *SheetBuilder {
-	var editors *sheets.Editors
+// SetImageFormula writes =IMAGE("url") into a cell, showing the image inside the cell.
+// Double quotes in url are escaped. The Sheets API cannot place images over cells,
+// so there is no overlay counterpart.
+func (sb *SheetBuilder) SetImageFormula(row int, col int, url string) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("SetImageFormula: invalid row: %d", row))
 
-	if !warningOnly {
-		if users == nil {
-			users = []string{}
-		}
+		return sb
+	}
 
-		editors = &sheets.Editors{
-			Users: users,
-		}
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("SetImageFormula: invalid col: %d", col))
+
+		return sb
 	}
 
-	req := &sheets.Request{
-		AddProtectedRange: &sheets.AddProtectedRangeRequest{
-			ProtectedRange: &sheets.ProtectedRange{
-				Range:       rng,
-				Description: desc,
-				WarningOnly: warningOnly,
-				Editors:     editors,
-			},
-		},
+	if url == "" {
+		sb.b.appendError(errors.New("SetImageFormula: url should not be empty"))
+
+		return sb
 	}
 
-	sb.b.AppendRequest(req)
+	formula := `=IMAGE("` + strings.ReplaceAll(url, `"`, `""`) + `")`
 
-	return sb
+	return sb.updateCell(row, col, formulaCell(formula), "userEnteredValue")
 }
 
-// SetForegroundColor sets the text color for the specified range.
-func (sb *SheetBuilder) SetForegroundColor(rect *Rect, color *sheets.Color) *SheetBuilder {
-	if sb.isRectInvalid(rect, "SetForegroundColor", "rect") {
-		return sb
+// formulaCell returns a CellData holding formula, adding a leading "=" if missing.
+func formulaCell(formula string) *sheets.CellData {
+	if !strings.HasPrefix(formula, "=") {
+		formula = "=" + formula
 	}
 
-	if color == nil {
-		sb.b.appendError(errors.New("SetForegroundColor: color should not be nil"))
-
-		return sb
+	return &sheets.CellData{
+		UserEnteredValue: &sheets.ExtendedValue{FormulaValue: &formula},
 	}
+}
 
+// updateCell appends an UpdateCells request for a single cell. row and col must already be validated.
+func (sb *SheetBuilder) updateCell(row int, col int, cell *sheets.CellData, fields string) *SheetBuilder {
 	req := &sheets.Request{
-		RepeatCell: &sheets.RepeatCellRequest{
-			Range: &sheets.GridRange{
-				SheetId:          sb.sheetID,
-				StartRowIndex:    int64(rect.Row),
-				EndRowIndex:      int64(rect.Row + rect.Height),
-				StartColumnIndex: int64(rect.Col),
-				EndColumnIndex:   int64(rect.Col + rect.Width),
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:     sb.sheetID,
+				RowIndex:    int64(row),
+				ColumnIndex: int64(col),
 			},
-			Cell: &sheets.CellData{
-				UserEnteredFormat: &sheets.CellFormat{
-					TextFormat: &sheets.TextFormat{
-						ForegroundColor: color,
-					},
-				},
+			Rows: []*sheets.RowData{
+				{Values: []*sheets.CellData{cell}},
 			},
-			Fields: "userEnteredFormat.textFormat.foregroundColor",
+			Fields: fields,
 		},
 	}
 
@@ -591,20 +662,1059 @@ func (sb *SheetBuilder) SetForegroundColor(rect *Rect, color *sheets.Color) *She
 	return sb
 }
 
-// SetBackgroundColor
-func (sb *SheetBuilder) SetBackgroundColor(rect *Rect, color *sheets.Color) *SheetBuilder {
-	if sb.isRectInvalid(rect, "SetBackgroundColor", "rect") {
+// SetRowValues
+func (sb *SheetBuilder) SetRowValues(row int, col int, values []any) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("SetRowValues: invalid row: %d", row))
+
 		return sb
 	}
 
-	if color == nil {
-		sb.b.appendError(errors.New("SetBackgroundColor: color should not be nil"))
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("SetRowValues: invalid col: %d", col))
 
 		return sb
 	}
 
-	req := &sheets.Request{
-		RepeatCell: &sheets.RepeatCellRequest{
+	if len(values) == 0 {
+		sb.b.appendError(errors.New("SetRowValues: values should not be nil or empty"))
+
+		return sb
+	}
+
+	if sb.b.skipEmpty {
+		return sb.setNonEmptyValues("SetRowValues", row, col, [][]any{values})
+	}
+
+	cells := make([]*sheets.CellData, 0, len(values))
+
+	for _, v := range values {
+		cells = append(cells, sb.toCellData(v, "SetRowValues"))
+	}
+
+	req := &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:     sb.sheetID,
+				RowIndex:    int64(row),
+				ColumnIndex: int64(col),
+			},
+			Rows: []*sheets.RowData{
+				{Values: cells},
+			},
+			Fields: "userEnteredValue",
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// SetOffsetRow writes the values of an OffsetSheetRow into row,
+// starting at the column equal to the row's offset.
+func (sb *SheetBuilder) SetOffsetRow(row int, or *OffsetSheetRow) *SheetBuilder {
+	if or == nil {
+		sb.b.appendError(errors.New("SetOffsetRow: row should not be nil"))
+
+		return sb
+	}
+
+	if or.Len() == 0 {
+		sb.b.appendError(errors.New("SetOffsetRow: row should not be empty"))
+
+		return sb
+	}
+
+	return sb.SetRowValues(row, or.Offset(), or.Slice())
+}
+
+// SetColValues sets values vertically starting from the specified cell.
+// It automatically converts a 1D slice into a vertical 2D slice.
+func (sb *SheetBuilder) SetColValues(row int, col int, values []any) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("SetColValues: invalid row: %d", row))
+
+		return sb
+	}
+
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("SetColValues: invalid col: %d", col))
+
+		return sb
+	}
+
+	if len(values) == 0 {
+		sb.b.appendError(errors.New("SetColValues: values should not be nil or empty"))
+
+		return sb
+	}
+
+	verticalData := make([][]any, len(values))
+
+	for i, v := range values {
+		verticalData[i] = []any{v}
+	}
+
+	return sb.setRangeValues("SetColValues", row, col, verticalData)
+}
+
+// SetRangeValues
+func (sb *SheetBuilder) SetRangeValues(row int, col int, values [][]any) *SheetBuilder {
+	return sb.setRangeValues("SetRangeValues", row, col, values)
+}
+
+// setRangeValues writes values starting at (row, col). label prefixes recorded errors.
+func (sb *SheetBuilder) setRangeValues(label string, row int, col int, values [][]any) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid row: %d", label, row))
+
+		return sb
+	}
+
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid col: %d", label, col))
+
+		return sb
+	}
+
+	if len(values) == 0 {
+		sb.b.appendError(fmt.Errorf("%s: values should not be nil or empty", label))
+
+		return sb
+	}
+
+	if sb.b.skipEmpty {
+		return sb.setNonEmptyValues(label, row, col, values)
+	}
+
+	rows, ok := sb.homogeneousRows(values)
+	if !ok {
+		rows = make([]*sheets.RowData, 0, len(values))
+
+		for _, rowVals := range values {
+			cells := make([]*sheets.CellData, 0, len(rowVals))
+
+			for _, v := range rowVals {
+				cells = append(cells, sb.toCellData(v, label))
+			}
+
+			rows = append(rows, &sheets.RowData{
+				Values: cells,
+			})
+		}
+	}
+
+	req := &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:     sb.sheetID,
+				RowIndex:    int64(row),
+				ColumnIndex: int64(col),
+			},
+			Rows:   rows,
+			Fields: "userEnteredValue",
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// homogeneousRows is a fast path for SetRangeValues when every cell is a string, or every cell
+// is a number (any int, uint or float kind). A cheap type-check pass runs first, so a mismatch
+// anywhere costs no allocation; the cells are then allocated in bulk instead of one by one.
+func (sb *SheetBuilder) homogeneousRows(values [][]any) ([]*sheets.RowData, bool) {
+	if len(values[0]) == 0 {
+		return nil, false
+	}
+
+	_, isString := values[0][0].(string)
+
+	n := 0
+
+	for _, rowVals := range values {
+		for _, v := range rowVals {
+			if isString {
+				if _, ok := v.(string); !ok {
+					return nil, false
+				}
+			} else if _, ok := toFloat64(v); !ok {
+				return nil, false
+			}
+		}
+
+		n += len(rowVals)
+	}
+
+	raw := sb.b.inputMode == InputModeRaw
+
+	cellBuf := make([]sheets.CellData, n)
+	valueBuf := make([]sheets.ExtendedValue, n)
+	ptrBuf := make([]*sheets.CellData, n)
+	rowBuf := make([]sheets.RowData, len(values))
+	rows := make([]*sheets.RowData, len(values))
+
+	var strBuf []string
+
+	var numBuf []float64
+
+	if isString {
+		strBuf = make([]string, n)
+	} else {
+		numBuf = make([]float64, n)
+	}
+
+	k := 0
+
+	for r, rowVals := range values {
+		start := k
+
+		for _, v := range rowVals {
+			ev := &valueBuf[k]
+
+			if isString {
+				strBuf[k] = v.(string)
+
+				// toCellData と同じく "=" で始まるなら数式として扱う
+				if len(strBuf[k]) > 0 && strBuf[k][0] == '=' && !raw {
+					ev.FormulaValue = &strBuf[k]
+				} else {
+					ev.StringValue = &strBuf[k]
+				}
+			} else {
+				numBuf[k], _ = toFloat64(v)
+				ev.NumberValue = &numBuf[k]
+			}
+
+			cellBuf[k].UserEnteredValue = ev
+			ptrBuf[k] = &cellBuf[k]
+			k++
+		}
+
+		rowBuf[r].Values = ptrBuf[start:k:k]
+		rows[r] = &rowBuf[r]
+	}
+
+	return rows, true
+}
+
+// SetMatrixWithFormat writes values into rect and applies format to every cell in a single request.
+// fields is the format field mask (e.g. "userEnteredFormat.backgroundColor");
+// userEnteredValue is always updated. values must match the rect's dimensions.
+func (sb *SheetBuilder) SetMatrixWithFormat(rect *Rect, values [][]any, format *sheets.CellFormat, fields string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetMatrixWithFormat", "rect") {
+		return sb
+	}
+
+	if format == nil {
+		sb.b.appendError(errors.New("SetMatrixWithFormat: format should not be nil"))
+
+		return sb
+	}
+
+	if fields == "" {
+		sb.b.appendError(errors.New("SetMatrixWithFormat: fields should not be empty"))
+
+		return sb
+	}
+
+	if len(values) != rect.Height {
+		sb.b.appendError(fmt.Errorf("SetMatrixWithFormat: values has %d rows, rect.Height is %d", len(values), rect.Height))
+
+		return sb
+	}
+
+	rows := make([]*sheets.RowData, 0, len(values))
+
+	for r, rowVals := range values {
+		if len(rowVals) != rect.Width {
+			sb.b.appendError(fmt.Errorf("SetMatrixWithFormat: values[%d] has %d columns, rect.Width is %d", r, len(rowVals), rect.Width))
+
+			return sb
+		}
+
+		cells := make([]*sheets.CellData, 0, len(rowVals))
+
+		for _, v := range rowVals {
+			cell := sb.toCellData(v, "SetMatrixWithFormat")
+			cell.UserEnteredFormat = format
+
+			cells = append(cells, cell)
+		}
+
+		rows = append(rows, &sheets.RowData{
+			Values: cells,
+		})
+	}
+
+	req := &sheets.Request{
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Range:  sb.gridRange(rect),
+			Rows:   rows,
+			Fields: "userEnteredValue," + fields,
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// setNonEmptyValues writes only the non-empty cells of values, leaving the other cells unchanged.
+// UpdateCells cannot skip cells inside its range, so each run of non-empty cells needs its own
+// range; runs covering the same columns on consecutive rows are merged into one request.
+func (sb *SheetBuilder) setNonEmptyValues(label string, row int, col int, values [][]any) *SheetBuilder {
+	type block struct {
+		start, end int // column span, relative to col
+		req        *sheets.UpdateCellsRequest
+	}
+
+	var open []block
+
+	for r, rowVals := range values {
+		next := make([]block, 0, len(open))
+		i := 0
+
+		for _, span := range nonEmptyRuns(rowVals) {
+			// 前の行のブロックのうち、この run より左にあるものは閉じる
+			for i < len(open) && open[i].start < span[0] {
+				sb.b.AppendRequest(&sheets.Request{UpdateCells: open[i].req})
+				i++
+			}
+
+			cells := make([]*sheets.CellData, 0, span[1]-span[0])
+
+			for _, v := range rowVals[span[0]:span[1]] {
+				cells = append(cells, sb.toCellData(v, label))
+			}
+
+			if i < len(open) && open[i].start == span[0] && open[i].end == span[1] {
+				open[i].req.Rows = append(open[i].req.Rows, &sheets.RowData{Values: cells})
+				next = append(next, open[i])
+				i++
+
+				continue
+			}
+
+			next = append(next, block{
+				start: span[0],
+				end:   span[1],
+				req: &sheets.UpdateCellsRequest{
+					Start: &sheets.GridCoordinate{
+						SheetId:     sb.sheetID,
+						RowIndex:    int64(row + r),
+						ColumnIndex: int64(col + span[0]),
+					},
+					Rows:   []*sheets.RowData{{Values: cells}},
+					Fields: "userEnteredValue",
+				},
+			})
+		}
+
+		for ; i < len(open); i++ {
+			sb.b.AppendRequest(&sheets.Request{UpdateCells: open[i].req})
+		}
+
+		open = next
+	}
+
+	for _, blk := range open {
+		sb.b.AppendRequest(&sheets.Request{UpdateCells: blk.req})
+	}
+
+	return sb
+}
+
+// nonEmptyRuns returns the [start, end) spans of consecutive non-empty cells in rowVals.
+func nonEmptyRuns(rowVals []any) [][2]int {
+	var runs [][2]int
+
+	start := -1
+
+	for c := 0; c <= len(rowVals); c++ {
+		if c < len(rowVals) && !isEmptyCell(rowVals[c]) {
+			if start < 0 {
+				start = c
+			}
+
+			continue
+		}
+
+		if start >= 0 {
+			runs = append(runs, [2]int{start, c})
+			start = -1
+		}
+	}
+
+	return runs
+}
+
+// SetRangeValuesByColumn sets values from column-major data, where the outer slice is columns
+// and the inner slice is rows. Cells below the end of a shorter column are left unchanged,
+// as with the missing trailing cells of a shorter row in SetRangeValues.
+// Adjacent columns of the same length are written by one request.
+func (sb *SheetBuilder) SetRangeValuesByColumn(row int, col int, columns [][]any) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("SetRangeValuesByColumn: invalid row: %d", row))
+
+		return sb
+	}
+
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("SetRangeValuesByColumn: invalid col: %d", col))
+
+		return sb
+	}
+
+	if len(columns) == 0 {
+		sb.b.appendError(errors.New("SetRangeValuesByColumn: columns should not be nil or empty"))
+
+		return sb
+	}
+
+	height := 0
+
+	for _, c := range columns {
+		height = max(height, len(c))
+	}
+
+	if height == 0 {
+		sb.b.appendError(errors.New("SetRangeValuesByColumn: columns should not be empty"))
+
+		return sb
+	}
+
+	// 空セルで埋めるとそのセルが消えるので、同じ長さの列ごとにまとめて書き込む
+	for start := 0; start < len(columns); {
+		end := start + 1
+
+		for end < len(columns) && len(columns[end]) == len(columns[start]) {
+			end++
+		}
+
+		if h := len(columns[start]); h > 0 {
+			values := make([][]any, h)
+
+			for r := range values {
+				values[r] = make([]any, end-start)
+
+				for c := start; c < end; c++ {
+					values[r][c-start] = columns[c][r]
+				}
+			}
+
+			sb.setRangeValues("SetRangeValuesByColumn", row, col+start, values)
+		}
+
+		start = end
+	}
+
+	return sb
+}
+
+// toCellData converts a Go value into a cell. nil yields an empty cell and
+// unsupported types record an error prefixed with label.
+// A time.Time is written as a serial number, so set a date format (e.g. with SetNumberFormat)
+// to display it as a date; the builder has no locale to choose one.
+func (sb *SheetBuilder) toCellData(v any, label string) *sheets.CellData {
+	cd := &sheets.CellData{}
+
+	switch val := v.(type) {
+	case string:
+		// "=" で始まるなら数式として扱う (RAW モードでは常に文字列)
+		if len(val) > 0 && val[0] == '=' && sb.b.inputMode != InputModeRaw {
+			cd.UserEnteredValue = &sheets.ExtendedValue{FormulaValue: &val}
+		} else {
+			cd.UserEnteredValue = &sheets.ExtendedValue{StringValue: &val}
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		f, _ := toFloat64(val)
+		cd.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &f}
+	case float64:
+		cd.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &val}
+	case bool:
+		cd.UserEnteredValue = &sheets.ExtendedValue{BoolValue: &val}
+	case time.Time:
+		// シリアル値として書き込むだけなので、日付として表示するには別途書式を設定する
+		f := timeToSerial(val)
+		cd.UserEnteredValue = &sheets.ExtendedValue{NumberValue: &f}
+	case nil:
+		// 空セル
+	default:
+		sb.b.appendError(fmt.Errorf("%s: unsupported value type: %T", label, v))
+	}
+
+	return cd
+}
+
+// toFloat64 converts an int, uint or float value to float64.
+// It returns false for any other type.
+func toFloat64(v any) (float64, bool) {
+	switch val := v.(type) {
+	case int:
+		return float64(val), true
+	case int8:
+		return float64(val), true
+	case int16:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case uint:
+		return float64(val), true
+	case uint8:
+		return float64(val), true
+	case uint16:
+		return float64(val), true
+	case uint32:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	case float32:
+		return float64(val), true
+	case float64:
+		return val, true
+	}
+
+	return 0, false
+}
+
+// ProtectRange protects the specified area.
+// If warningOnly is true, it shows a warning when editing but allows changes.
+// If warningOnly is false, it restricts editing to the specified users (or owner only if users is empty).
+func (sb *SheetBuilder) ProtectRange(rect *Rect, description string, users []string, warningOnly bool) *SheetBuilder {
+	if sb.isRectInvalid(rect, "ProtectRange", "rect") {
+		return sb
+	}
+
+	return sb.addProtectedRangeRequest(description, users, warningOnly, &sheets.GridRange{
+		SheetId:          int64(sb.sheetID),
+		StartRowIndex:    int64(rect.Row),
+		EndRowIndex:      int64(rect.Row + rect.Height),
+		StartColumnIndex: int64(rect.Col),
+		EndColumnIndex:   int64(rect.Col + rect.Width),
+	})
+}
+
+// ProtectSheet protects the entire sheet.
+func (sb *SheetBuilder) ProtectSheet(description string, users []string, warningOnly bool) *SheetBuilder {
+	return sb.addProtectedRangeRequest(description, users, warningOnly, &sheets.GridRange{
+		SheetId: int64(sb.sheetID),
+	})
+}
+
+// ProtectSheetExcept protects the entire sheet except the editable ranges.
+// Editing outside the editable ranges is restricted to the specified users (or owner only if users is empty).
+func (sb *SheetBuilder) ProtectSheetExcept(description string, editable []*Rect, users []string) *SheetBuilder {
+	if len(editable) == 0 {
+		sb.b.appendError(errors.New("ProtectSheetExcept: editable should not be nil or empty"))
+
+		return sb
+	}
+
+	unprotected := make([]*sheets.GridRange, 0, len(editable))
+
+	for i, rect := range editable {
+		if sb.isRectInvalid(rect, "ProtectSheetExcept", fmt.Sprintf("editable[%d]", i)) {
+			return sb
+		}
+
+		unprotected = append(unprotected, sb.gridRange(rect))
+	}
+
+	return sb.addProtectedRangeRequest(description, users, false, &sheets.GridRange{
+		SheetId: int64(sb.sheetID),
+	}, unprotected...)
+}
+
+// addProtectedRangeRequest
+func (sb *SheetBuilder) addProtectedRangeRequest(desc string, users []string, warningOnly bool, rng *sheets.GridRange, unprotected ...*sheets.GridRange) *SheetBuilder {
+	var editors *sheets.Editors
+
+	if !warningOnly {
+		if users == nil {
+			users = []string{}
+		}
+
+		editors = &sheets.Editors{
+			Users: users,
+		}
+	}
+
+	return sb.appendProtectedRange(desc, warningOnly, editors, rng, unprotected...)
+}
+
+// appendProtectedRange appends an AddProtectedRange request with the given editors.
+func (sb *SheetBuilder) appendProtectedRange(desc string, warningOnly bool, editors *sheets.Editors, rng *sheets.GridRange, unprotected ...*sheets.GridRange) *SheetBuilder {
+	req := &sheets.Request{
+		AddProtectedRange: &sheets.AddProtectedRangeRequest{
+			ProtectedRange: &sheets.ProtectedRange{
+				Range:             rng,
+				Description:       desc,
+				WarningOnly:       warningOnly,
+				Editors:           editors,
+				UnprotectedRanges: unprotected,
+			},
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// ProtectRangeDomain protects the specified area but lets any user in the owner's domain edit it.
+func (sb *SheetBuilder) ProtectRangeDomain(rect *Rect, description string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "ProtectRangeDomain", "rect") {
+		return sb
+	}
+
+	editors := &sheets.Editors{
+		DomainUsersCanEdit: true,
+		ForceSendFields:    []string{"DomainUsersCanEdit"},
+	}
+
+	return sb.appendProtectedRange(description, false, editors, sb.gridRange(rect))
+}
+
+// tempProtectionPrefix marks protections created by ProtectRangeTemp.
+// The expiry follows the prefix in RFC 3339 format.
+const tempProtectionPrefix = "haresheet:temp-until="
+
+// ProtectRangeTemp protects the specified area until the given time, restricting editing to users
+// (or owner only if users is empty). Expired protections are removed by Client.CleanupExpiredProtections.
+func (sb *SheetBuilder) ProtectRangeTemp(rect *Rect, until time.Time, users []string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "ProtectRangeTemp", "rect") {
+		return sb
+	}
+
+	if until.IsZero() {
+		sb.b.appendError(errors.New("ProtectRangeTemp: until should not be zero"))
+
+		return sb
+	}
+
+	desc := tempProtectionPrefix + until.UTC().Format(time.RFC3339)
+
+	return sb.addProtectedRangeRequest(desc, users, false, sb.gridRange(rect))
+}
+
+// parseTempProtection returns the expiry encoded in a ProtectRangeTemp description.
+func parseTempProtection(desc string) (time.Time, bool) {
+	s, ok := strings.CutPrefix(desc, tempProtectionPrefix)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	until, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return until, true
+}
+
+// SetForegroundColor sets the text color for the specified range.
+func (sb *SheetBuilder) SetForegroundColor(rect *Rect, color *sheets.Color) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetForegroundColor", "rect") {
+		return sb
+	}
+
+	if color == nil {
+		sb.b.appendError(errors.New("SetForegroundColor: color should not be nil"))
+
+		return sb
+	}
+
+	cell := &sheets.CellData{
+		UserEnteredFormat: &sheets.CellFormat{
+			TextFormat: &sheets.TextFormat{
+				ForegroundColor: color,
+			},
+		},
+	}
+
+	return sb.repeatCell(rect, cell, "userEnteredFormat.textFormat.foregroundColor")
+}
+
+// SetBackgroundColor
+func (sb *SheetBuilder) SetBackgroundColor(rect *Rect, color *sheets.Color) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetBackgroundColor", "rect") {
+		return sb
+	}
+
+	if color == nil {
+		sb.b.appendError(errors.New("SetBackgroundColor: color should not be nil"))
+
+		return sb
+	}
+
+	cell := &sheets.CellData{
+		UserEnteredFormat: &sheets.CellFormat{
+			BackgroundColor: color,
+		},
+	}
+
+	return sb.repeatCell(rect, cell, "userEnteredFormat.backgroundColor")
+}
+
+// SetTextStyle applies the fields set in style to the text in rect, leaving other text formatting unchanged.
+func (sb *SheetBuilder) SetTextStyle(rect *Rect, style TextStyle) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetTextStyle", "rect") {
+		return sb
+	}
+
+	if style.FontSize < 0 {
+		sb.b.appendError(fmt.Errorf("SetTextStyle: invalid FontSize: %d", style.FontSize))
+
+		return sb
+	}
+
+	tf := &sheets.TextFormat{}
+	fields := make([]string, 0, 6)
+
+	flags := []struct {
+		name string
+		v    *bool
+		dst  *bool
+		json string
+	}{
+		{"Bold", style.Bold, &tf.Bold, "bold"},
+		{"Italic", style.Italic, &tf.Italic, "italic"},
+		{"Underline", style.Underline, &tf.Underline, "underline"},
+		{"Strikethrough", style.Strikethrough, &tf.Strikethrough, "strikethrough"},
+	}
+
+	for _, f := range flags {
+		if f.v == nil {
+			continue
+		}
+
+		*f.dst = *f.v
+		tf.ForceSendFields = append(tf.ForceSendFields, f.name)
+		fields = append(fields, "userEnteredFormat.textFormat."+f.json)
+	}
+
+	if style.FontFamily != "" {
+		tf.FontFamily = style.FontFamily
+		fields = append(fields, "userEnteredFormat.textFormat.fontFamily")
+	}
+
+	if style.FontSize > 0 {
+		tf.FontSize = int64(style.FontSize)
+		fields = append(fields, "userEnteredFormat.textFormat.fontSize")
+	}
+
+	if len(fields) == 0 {
+		sb.b.appendError(errors.New("SetTextStyle: style has no fields set"))
+
+		return sb
+	}
+
+	cell := &sheets.CellData{
+		UserEnteredFormat: &sheets.CellFormat{TextFormat: tf},
+	}
+
+	return sb.repeatCell(rect, cell, strings.Join(fields, ","))
+}
+
+// SetHorizontalAlignment sets the horizontal alignment of rect. An empty align means HAlignLeft.
+func (sb *SheetBuilder) SetHorizontalAlignment(rect *Rect, align HAlign) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetHorizontalAlignment", "rect") {
+		return sb
+	}
+
+	if align == "" {
+		align = HAlignLeft
+	}
+
+	cell := &sheets.CellData{
+		UserEnteredFormat: &sheets.CellFormat{HorizontalAlignment: string(align)},
+	}
+
+	return sb.repeatCell(rect, cell, "userEnteredFormat.horizontalAlignment")
+}
+
+// SetVerticalAlignment sets the vertical alignment of rect. An empty align means VAlignBottom.
+func (sb *SheetBuilder) SetVerticalAlignment(rect *Rect, align VAlign) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetVerticalAlignment", "rect") {
+		return sb
+	}
+
+	if align == "" {
+		align = VAlignBottom
+	}
+
+	cell := &sheets.CellData{
+		UserEnteredFormat: &sheets.CellFormat{VerticalAlignment: string(align)},
+	}
+
+	return sb.repeatCell(rect, cell, "userEnteredFormat.verticalAlignment")
+}
+
+// SetBorders draws the borders of rect. Only the non-nil sides of borders are sent.
+func (sb *SheetBuilder) SetBorders(rect *Rect, borders *Borders) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetBorders", "rect") {
+		return sb
+	}
+
+	if borders == nil {
+		sb.b.appendError(errors.New("SetBorders: borders should not be nil"))
+
+		return sb
+	}
+
+	req := &sheets.UpdateBordersRequest{
+		Range:           sb.gridRange(rect),
+		Top:             toSheetsBorder(borders.Top),
+		Bottom:          toSheetsBorder(borders.Bottom),
+		Left:            toSheetsBorder(borders.Left),
+		Right:           toSheetsBorder(borders.Right),
+		InnerHorizontal: toSheetsBorder(borders.InnerHorizontal),
+		InnerVertical:   toSheetsBorder(borders.InnerVertical),
+	}
+
+	if req.Top == nil && req.Bottom == nil && req.Left == nil && req.Right == nil &&
+		req.InnerHorizontal == nil && req.InnerVertical == nil {
+		sb.b.appendError(errors.New("SetBorders: borders has no sides set"))
+
+		return sb
+	}
+
+	sb.b.AppendRequest(&sheets.Request{UpdateBorders: req})
+
+	return sb
+}
+
+// toSheetsBorder converts a Border to the API type. A nil border returns nil.
+func toSheetsBorder(b *Border) *sheets.Border {
+	if b == nil {
+		return nil
+	}
+
+	style := b.Style
+
+	if style == "" {
+		style = "SOLID"
+	}
+
+	return &sheets.Border{
+		Style: style,
+		Color: b.Color,
+	}
+}
+
+// RepeatCellRaw applies cell to every cell in the specified range, updating only the given fields.
+// Use it for CellData/CellFormat fields that have no dedicated method.
+func (sb *SheetBuilder) RepeatCellRaw(rect *Rect, cell *sheets.CellData, fields string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "RepeatCellRaw", "rect") {
+		return sb
+	}
+
+	if cell == nil {
+		sb.b.appendError(errors.New("RepeatCellRaw: cell should not be nil"))
+
+		return sb
+	}
+
+	if fields == "" {
+		sb.b.appendError(errors.New("RepeatCellRaw: fields should not be empty"))
+
+		return sb
+	}
+
+	return sb.repeatCell(rect, cell, fields)
+}
+
+// repeatCell appends a RepeatCell request. rect must already be validated.
+func (sb *SheetBuilder) repeatCell(rect *Rect, cell *sheets.CellData, fields string) *SheetBuilder {
+	req := &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range:  sb.gridRange(rect),
+			Cell:   cell,
+			Fields: fields,
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// SetTabColor sets the tab color using the API's Color struct.
+func (sb *SheetBuilder) SetTabColor(color *sheets.Color) *SheetBuilder {
+	if color == nil {
+		sb.b.appendError(errors.New("SetTabColor: color should not be nil"))
+
+		return sb
+	}
+
+	req := &sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId:  sb.sheetID,
+				TabColor: color, // そのまま渡す
+			},
+			Fields: "tabColor",
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// InsertRange inserts empty cells into the specified range and shifts existing cells.
+func (sb *SheetBuilder) InsertRange(rect *Rect, shiftDimension ShiftDimensionType) *SheetBuilder {
+	if sb.isRectInvalid(rect, "InsertRange", "rect") {
+		return sb
+	}
+
+	if shiftDimension == "" {
+		shiftDimension = ShiftDimensionTypeRows
+	}
+
+	req := &sheets.Request{
+		InsertRange: &sheets.InsertRangeRequest{
+			Range:          sb.gridRange(rect),
+			ShiftDimension: string(shiftDimension),
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// DuplicateRows inserts times copies of the count rows starting at srcRow immediately below them.
+// Entire rows are inserted and copied, including their formatting.
+func (sb *SheetBuilder) DuplicateRows(srcRow int, count int, times int) *SheetBuilder {
+	if srcRow < 0 {
+		sb.b.appendError(fmt.Errorf("DuplicateRows: invalid srcRow: %d", srcRow))
+
+		return sb
+	}
+
+	if count <= 0 {
+		sb.b.appendError(fmt.Errorf("DuplicateRows: invalid count: %d", count))
+
+		return sb
+	}
+
+	if times <= 0 {
+		sb.b.appendError(fmt.Errorf("DuplicateRows: invalid times: %d", times))
+
+		return sb
+	}
+
+	src := &Rect{Row: srcRow, Col: 0, Height: count, Width: rangeUnset}
+
+	sb.InsertRange(&Rect{Row: srcRow + count, Col: 0, Height: count * times, Width: rangeUnset}, ShiftDimensionTypeRows)
+
+	for i := 1; i <= times; i++ {
+		sb.CopyRange(sb.sheetID, src, srcRow+count*i, 0, PasteTypeNormal)
+	}
+
+	return sb
+}
+
+// InsertAndCopyRange inserts blank cells (shifting down) and then copies data from another sheet.
+// It internally calls InsertRange and CopyRange.
+func (sb *SheetBuilder) InsertAndCopyRange(srcSheetID int64, src *Rect, dstR int, dstC int, pasteType PasteType) *SheetBuilder {
+	if srcSheetID < 0 {
+		sb.b.appendError(fmt.Errorf("InsertAndCopyRange: invalid src sheet id: %d", srcSheetID))
+
+		return sb
+	}
+
+	if sb.isRectInvalid(src, "InsertAndCopyRange", "src") {
+		return sb
+	}
+
+	if dstR < 0 {
+		sb.b.appendError(fmt.Errorf("InsertAndCopyRange: invalid dst row: %d", dstR))
+
+		return sb
+	}
+
+	if dstC < 0 {
+		sb.b.appendError(fmt.Errorf("InsertAndCopyRange: invalid dst column: %d", dstR))
+
+		return sb
+	}
+
+	pasteType = sb.b.pasteTypeOr(pasteType)
+
+	// 1. 挿入先の範囲を計算（コピー元と同じサイズ）
+	dstRect := &Rect{
+		Row:    dstR,
+		Col:    dstC,
+		Height: src.Height, // 高さはコピー元と同じ
+		Width:  src.Width,  // 幅もコピー元と同じ
+	}
+
+	// 2. 空白を挿入（既存セルを下にずらす）
+	sb.InsertRange(dstRect, ShiftDimensionTypeRows)
+
+	// 3. コピー元の座標補正（※同一シートの場合のみ発動する安全装置）
+	// 挿入によって自分が下にズレてしまった場合、コピー元の座標も追従させる
+	actualSrc := *src
+
+	if sb.sheetID == srcSheetID && dstR <= src.Row {
+		actualSrc.Row += src.Height
+	}
+
+	sb.CopyRange(srcSheetID, &actualSrc, dstR, dstC, pasteType)
+
+	return sb
+}
+
+// DeleteRange deletes the specified range and shifts other cells to fill the gap.
+func (sb *SheetBuilder) DeleteRange(rect *Rect, shiftDimension ShiftDimensionType) *SheetBuilder {
+	if sb.isRectInvalid(rect, "DeleteRange", "rect") {
+		return sb
+	}
+
+	if shiftDimension == "" {
+		shiftDimension = ShiftDimensionTypeRows
+	}
+
+	req := &sheets.Request{
+		DeleteRange: &sheets.DeleteRangeRequest{
+			Range: &sheets.GridRange{
+				SheetId:          sb.sheetID,
+				StartRowIndex:    int64(rect.Row),
+				EndRowIndex:      int64(rect.Row + rect.Height),
+				StartColumnIndex: int64(rect.Col),
+				EndColumnIndex:   int64(rect.Col + rect.Width),
+			},
+			ShiftDimension: string(shiftDimension),
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// SetLink sets a hyperlink to the specified range.
+// url: Can be an external URL (http://...) or an internal sheet link (#gid=...).
+func (sb *SheetBuilder) SetLink(rect *Rect, url string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetLink", "rect") {
+		return sb
+	}
+
+	if url == "" {
+		sb.b.appendError(errors.New("SetLink: url should not be empty"))
+
+		return sb
+	}
+
+	req := &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
 			Range: &sheets.GridRange{
 				SheetId:          sb.sheetID,
 				StartRowIndex:    int64(rect.Row),
@@ -612,35 +1722,348 @@ func (sb *SheetBuilder) SetBackgroundColor(rect *Rect, color *sheets.Color) *She
 				StartColumnIndex: int64(rect.Col),
 				EndColumnIndex:   int64(rect.Col + rect.Width),
 			},
-			Cell: &sheets.CellData{
-				UserEnteredFormat: &sheets.CellFormat{
-					BackgroundColor: color,
-				},
+			Cell: &sheets.CellData{
+				UserEnteredFormat: &sheets.CellFormat{
+					TextFormat: &sheets.TextFormat{
+						Link: &sheets.Link{
+							Uri: url,
+						},
+						// 文字色は青、下線付きにするのが一般的ですが
+						// 既存の書式を壊さないよう、ここではリンク属性のみ設定します
+					},
+				},
+			},
+			// リンク情報だけを更新する
+			Fields: "userEnteredFormat.textFormat.link",
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// SetLinkToSheet sets a link to jump to a specific sheet ID within the same spreadsheet.
+func (sb *SheetBuilder) SetLinkToSheet(rect *Rect, targetSheetID int64) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetLinkToSheet", "rect") {
+		return sb
+	}
+
+	if targetSheetID <= 0 {
+		sb.b.appendError(fmt.Errorf("Row: invalid target sheet id: %d", targetSheetID))
+
+		return sb
+	}
+
+	// スプレッドシート内部リンクの形式 (#gid=ID) を生成
+	internalLink := fmt.Sprintf("#gid=%d", targetSheetID)
+
+	return sb.SetLink(rect, internalLink)
+}
+
+// SetHidden sets the visibility of the sheet.
+// true to hide, false to show.
+func (sb *SheetBuilder) SetHidden(hidden bool) *SheetBuilder {
+	req := &sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{
+				SheetId: sb.sheetID,
+				Hidden:  hidden,
+			},
+			Fields: "hidden",
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// Hide hides the sheet.
+func (sb *SheetBuilder) Hide() *SheetBuilder {
+	return sb.SetHidden(true)
+}
+
+// Show shows the sheet.
+func (sb *SheetBuilder) Show() *SheetBuilder {
+	return sb.SetHidden(false)
+}
+
+// SetColumnsHidden sets the visibility of columns.
+func (sb *SheetBuilder) SetColumnsHidden(startCol int, count int, hidden bool) *SheetBuilder {
+	if startCol <= 0 {
+		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid start column: %d", startCol))
+
+		return sb
+	}
+
+	if count <= 0 {
+		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid count: %d", count))
+
+		return sb
+	}
+
+	return sb.updateDimension("COLUMNS", startCol, count, &sheets.DimensionProperties{HiddenByUser: hidden}, "hiddenByUser")
+}
+
+// SetRowsHidden sets the visibility of rows.
+func (sb *SheetBuilder) SetRowsHidden(startRow int, count int, hidden bool) *SheetBuilder {
+	if startRow <= 0 {
+		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid start row: %d", startRow))
+
+		return sb
+	}
+
+	if count <= 0 {
+		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid count: %d", count))
+
+		return sb
+	}
+
+	sb.updateDimension("ROWS", startRow, count, &sheets.DimensionProperties{HiddenByUser: hidden}, "hiddenByUser")
+
+	return sb
+}
+
+// updateDimension is a generic helper to update row or column properties.
+// It handles the tedious API request construction.
+func (sb *SheetBuilder) updateDimension(dimension string, start int, count int, props *sheets.DimensionProperties, fields string) *SheetBuilder {
+	req := &sheets.Request{
+		UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+			Range: &sheets.DimensionRange{
+				SheetId:    sb.sheetID,
+				Dimension:  dimension,
+				StartIndex: int64(start),
+				EndIndex:   int64(start + count),
+			},
+			Properties: props,
+			Fields:     fields,
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// autoResizeDimension appends an AutoResizeDimensions request for rows or columns.
+func (sb *SheetBuilder) autoResizeDimension(dimension string, start int, count int) *SheetBuilder {
+	req := &sheets.Request{
+		AutoResizeDimensions: &sheets.AutoResizeDimensionsRequest{
+			Dimensions: &sheets.DimensionRange{
+				SheetId:    sb.sheetID,
+				Dimension:  dimension,
+				StartIndex: int64(start),
+				EndIndex:   int64(start + count),
+			},
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// RangeUnset represents a value indicating that the range parameter
+const rangeUnset = -1
+
+// clearValues creates a request to clear values.
+func (sb *SheetBuilder) clearValues(row int, col int, height int, width int) *SheetBuilder {
+	rng := &sheets.GridRange{
+		SheetId: sb.sheetID,
+	}
+
+	if row >= 0 {
+		rng.StartRowIndex = int64(row)
+	}
+
+	if col >= 0 {
+		rng.StartColumnIndex = int64(col)
+	}
+
+	if height > 0 {
+		start := int64(0)
+
+		if row > 0 {
+			start = int64(row)
+		}
+
+		rng.EndRowIndex = start + int64(height)
+	}
+
+	if width > 0 {
+		start := int64(0)
+
+		if col > 0 {
+			start = int64(col)
+		}
+
+		rng.EndColumnIndex = start + int64(width)
+	}
+
+	req := &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range:  rng,
+			Cell:   &sheets.CellData{},
+			Fields: "userEnteredValue", // Only clear values
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// ClearAllValues clears all values in the sheet.
+func (sb *SheetBuilder) ClearAllValues() *SheetBuilder {
+	return sb.clearValues(rangeUnset, rangeUnset, rangeUnset, rangeUnset)
+}
+
+// ResetSheetFormatting removes all formatting from the sheet, keeping values and formulas.
+// It is the counterpart of ClearAllValues.
+func (sb *SheetBuilder) ResetSheetFormatting() *SheetBuilder {
+	req := &sheets.Request{
+		RepeatCell: &sheets.RepeatCellRequest{
+			Range: &sheets.GridRange{
+				SheetId: sb.sheetID,
 			},
-			Fields: "userEnteredFormat.backgroundColor",
+			Cell:   &sheets.CellData{},
+			Fields: "userEnteredFormat",
 		},
 	}
 
-	sb.b.AppendRequest(req)
+	sb.b.AppendRequest(req)
+
+	return sb
+}
+
+// ClearRangeValues clears values in the specified rectangle.
+func (sb *SheetBuilder) ClearRangeValues(rect *Rect) *SheetBuilder {
+	if sb.isRectInvalid(rect, "ClearRangeValues", "rect") {
+		return sb
+	}
+
+	return sb.clearValues(rect.Row, rect.Col, rect.Height, rect.Width)
+}
+
+// ClearColValues clears values in the specified columns.
+func (sb *SheetBuilder) ClearColValues(col int, width int, skipRows int) *SheetBuilder {
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("ClearColValues: invalid col: %d", col))
+
+		return sb
+	}
+
+	if width < 1 {
+		sb.b.appendError(fmt.Errorf("ClearColValues: invalid width: %d", width))
+
+		return sb
+	}
+
+	if skipRows < 0 {
+		sb.b.appendError(fmt.Errorf("ClearColValues: invalid skipRows: %d", skipRows))
+
+		return sb
+	}
+
+	return sb.clearValues(skipRows, col, rangeUnset, width)
+}
+
+// ClearRowValues clears values in the specified rows.
+func (sb *SheetBuilder) ClearRowValues(row int, height int, skipCols int) *SheetBuilder {
+	if row < 0 {
+		sb.b.appendError(fmt.Errorf("ClearRowValues: invalid row: %d", row))
+
+		return sb
+	}
+
+	if height < 1 {
+		sb.b.appendError(fmt.Errorf("ClearRowValues: invalid height: %d", height))
+
+		return sb
+	}
+
+	if skipCols < 0 {
+		sb.b.appendError(fmt.Errorf("ClearRowValues: invalid skipCols: %d", skipCols))
+
+		return sb
+	}
+
+	return sb.clearValues(row, skipCols, height, rangeUnset)
+}
+
+func (sb *SheetBuilder) isRectInvalid(rect *Rect, label string, name string) bool {
+	if rect == nil {
+		sb.b.appendError(fmt.Errorf("%s: %s should not be nil", label, name))
+
+		return true
+	}
+
+	if rect.Row < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid %s.Row: %d", label, name, rect.Row))
+
+		return true
+	}
+
+	if rect.Col < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid %s.Col: %d", label, name, rect.Col))
+
+		return true
+	}
+
+	if rect.Height < 0 && rect.Height != rangeUnset {
+		sb.b.appendError(fmt.Errorf("%s: invalid %s.Height: %d", label, name, rect.Height))
+
+		return true
+	}
+
+	if rect.Width < 0 && rect.Width != rangeUnset {
+		sb.b.appendError(fmt.Errorf("%s: invalid %s.Width: %d", label, name, rect.Width))
+
+		return true
+	}
+
+	return false
+}
+
+// freeze creates a request to freeze rows and/or columns.
+// If a parameter is rangeUnset, it is ignored (not updated).
+func (sb *SheetBuilder) freeze(rows int, cols int) *SheetBuilder {
+	gridProps := &sheets.GridProperties{}
+
+	var fields []string
+
+	if rows < rangeUnset {
+		sb.b.appendError(fmt.Errorf("freeze: invalid rows: %d", rows))
+
+		return sb
+	} else if rows > rangeUnset {
+		gridProps.FrozenRowCount = int64(rows)
+
+		fields = append(fields, "gridProperties.frozenRowCount")
+	}
+
+	if cols < rangeUnset {
+		sb.b.appendError(fmt.Errorf("freeze: invalid cols: %d", cols))
 
-	return sb
-}
+		return sb
+	} else if cols > rangeUnset {
+		gridProps.FrozenColumnCount = int64(cols)
 
-// SetTabColor sets the tab color using the API's Color struct.
-func (sb *SheetBuilder) SetTabColor(color *sheets.Color) *SheetBuilder {
-	if color == nil {
-		sb.b.appendError(errors.New("SetTabColor: color should not be nil"))
+		fields = append(fields, "gridProperties.frozenColumnCount")
+	}
 
+	if len(fields) == 0 {
 		return sb
 	}
 
 	req := &sheets.Request{
 		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
 			Properties: &sheets.SheetProperties{
-				SheetId:  sb.sheetID,
-				TabColor: color, // そのまま渡す
+				SheetId:        sb.sheetID,
+				GridProperties: gridProps,
 			},
-			Fields: "tabColor",
+			Fields: strings.Join(fields, ","),
 		},
 	}
 
@@ -649,107 +2072,212 @@ func (sb *SheetBuilder) SetTabColor(color *sheets.Color) *SheetBuilder {
 	return sb
 }
 
-// InsertRange inserts empty cells into the specified range and shifts existing cells.
-func (sb *SheetBuilder) InsertRange(rect *Rect, shiftDimension ShiftDimensionType) *SheetBuilder {
-	if sb.isRectInvalid(rect, "InsertRange", "rect") {
-		return sb
-	}
+// Freeze freezes the specified number of rows and columns.
+// To leave a dimension unchanged, pass rangeUnset (or use FreezeRows/FreezeCols).
+// Passing 0 unfreezes the dimension.
+func (sb *SheetBuilder) Freeze(rows int, cols int) *SheetBuilder {
+	return sb.freeze(rows, cols)
+}
 
-	if shiftDimension == "" {
-		shiftDimension = ShiftDimensionTypeRows
+// FreezeRows freezes the first n rows.
+// Pass 0 to unfreeze rows. Columns remain unchanged.
+func (sb *SheetBuilder) FreezeRows(rows int) *SheetBuilder {
+	return sb.freeze(rows, rangeUnset)
+}
+
+// FreezeCols freezes the first n columns.
+// Pass 0 to unfreeze columns. Rows remain unchanged.
+func (sb *SheetBuilder) FreezeCols(cols int) *SheetBuilder {
+	return sb.freeze(rangeUnset, cols)
+}
+
+// gridRange converts rect into a GridRange on this sheet.
+// A Height or Width of rangeUnset leaves the corresponding end open.
+func (sb *SheetBuilder) gridRange(rect *Rect) *sheets.GridRange {
+	rng := sb.b.newGridRange()
+	rng.SheetId = sb.sheetID
+	rng.StartRowIndex = int64(rect.Row)
+	rng.StartColumnIndex = int64(rect.Col)
+
+	if rect.Height != rangeUnset {
+		rng.EndRowIndex = int64(rect.Row + rect.Height)
 	}
 
-	req := &sheets.Request{
-		InsertRange: &sheets.InsertRangeRequest{
-			Range: &sheets.GridRange{
-				SheetId:          sb.sheetID,
-				StartRowIndex:    int64(rect.Row),
-				EndRowIndex:      int64(rect.Row + rect.Height),
-				StartColumnIndex: int64(rect.Col),
-				EndColumnIndex:   int64(rect.Col + rect.Width),
-			},
-			ShiftDimension: string(shiftDimension),
-		},
+	if rect.Width != rangeUnset {
+		rng.EndColumnIndex = int64(rect.Col + rect.Width)
 	}
 
-	sb.b.AppendRequest(req)
+	return rng
+}
 
-	return sb
+// SetDropdownFromRange adds a dropdown whose options come from sourceRange on the same sheet.
+// If strict is true, values not in the list are rejected.
+func (sb *SheetBuilder) SetDropdownFromRange(rect *Rect, sourceRange *Rect, strict bool, opts ...ValidationOption) *SheetBuilder {
+	return sb.setDropdownFromRange("SetDropdownFromRange", rect, "", sourceRange, strict, opts)
 }
 
-// InsertAndCopyRange inserts blank cells (shifting down) and then copies data from another sheet.
-// It internally calls InsertRange and CopyRange.
-func (sb *SheetBuilder) InsertAndCopyRange(srcSheetID int64, src *Rect, dstR int, dstC int, pasteType PasteType) *SheetBuilder {
-	if srcSheetID < 0 {
-		sb.b.appendError(fmt.Errorf("InsertAndCopyRange: invalid src sheet id: %d", srcSheetID))
+// SetDropdownFromSheetRange adds a dropdown whose options come from sourceRange on the sheet titled sheetTitle.
+// If strict is true, values not in the list are rejected.
+func (sb *SheetBuilder) SetDropdownFromSheetRange(rect *Rect, sheetTitle string, sourceRange *Rect, strict bool, opts ...ValidationOption) *SheetBuilder {
+	if sheetTitle == "" {
+		sb.b.appendError(errors.New("SetDropdownFromSheetRange: sheetTitle should not be empty"))
 
 		return sb
 	}
 
-	if sb.isRectInvalid(src, "InsertAndCopyRange", "src") {
+	return sb.setDropdownFromRange("SetDropdownFromSheetRange", rect, sheetTitle, sourceRange, strict, opts)
+}
+
+// setDropdownFromRange emits a ONE_OF_RANGE validation referencing sourceRange.
+func (sb *SheetBuilder) setDropdownFromRange(label string, rect *Rect, sheetTitle string, sourceRange *Rect, strict bool, opts []ValidationOption) *SheetBuilder {
+	if sb.isRectInvalid(rect, label, "rect") {
 		return sb
 	}
 
-	if dstR < 0 {
-		sb.b.appendError(fmt.Errorf("InsertAndCopyRange: invalid dst row: %d", dstR))
+	if sb.isRectInvalid(sourceRange, label, "sourceRange") {
+		return sb
+	}
+
+	a1, err := rectToA1(sourceRange)
+	if err != nil {
+		sb.b.appendError(fmt.Errorf("%s: invalid sourceRange: %w", label, err))
 
 		return sb
 	}
 
-	if dstC < 0 {
-		sb.b.appendError(fmt.Errorf("InsertAndCopyRange: invalid dst column: %d", dstR))
+	if sheetTitle != "" {
+		a1 = quoteSheetTitle(sheetTitle) + "!" + a1
+	}
+
+	return sb.setDataValidation(rect, &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{
+			Type: "ONE_OF_RANGE",
+			Values: []*sheets.ConditionValue{
+				{UserEnteredValue: "=" + a1},
+			},
+		},
+		ShowCustomUi: true,
+		Strict:       strict,
+	}, opts...)
+}
+
+// SetDropdown adds a dropdown listing values to rect.
+// If strict is true, values not in the list are rejected.
+func (sb *SheetBuilder) SetDropdown(rect *Rect, values []string, strict bool, opts ...ValidationOption) *SheetBuilder {
+	return sb.setDropdown("SetDropdown", rect, values, strict, opts)
+}
 
+// ClearDataValidation removes any data validation from rect.
+func (sb *SheetBuilder) ClearDataValidation(rect *Rect) *SheetBuilder {
+	if sb.isRectInvalid(rect, "ClearDataValidation", "rect") {
 		return sb
 	}
 
-	if pasteType == "" {
-		pasteType = PasteTypeNormal
+	return sb.setDataValidation(rect, nil)
+}
+
+// SetCustomValidation validates rect with a CUSTOM_FORMULA rule such as "=ISNUMBER(A1)".
+// Relative references are evaluated from rect's top-left cell. helpText is shown when a cell is
+// selected; an empty helpText is omitted. If strict is true, invalid input is rejected.
+func (sb *SheetBuilder) SetCustomValidation(rect *Rect, formula string, strict bool, helpText string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetCustomValidation", "rect") {
+		return sb
 	}
 
-	// 1. 挿入先の範囲を計算（コピー元と同じサイズ）
-	dstRect := &Rect{
-		Row:    dstR,
-		Col:    dstC,
-		Height: src.Height, // 高さはコピー元と同じ
-		Width:  src.Width,  // 幅もコピー元と同じ
+	if strings.TrimPrefix(formula, "=") == "" {
+		sb.b.appendError(errors.New("SetCustomValidation: formula should not be empty"))
+
+		return sb
 	}
 
-	// 2. 空白を挿入（既存セルを下にずらす）
-	sb.InsertRange(dstRect, ShiftDimensionTypeRows)
+	if !strings.HasPrefix(formula, "=") {
+		formula = "=" + formula
+	}
 
-	// 3. コピー元の座標補正（※同一シートの場合のみ発動する安全装置）
-	// 挿入によって自分が下にズレてしまった場合、コピー元の座標も追従させる
-	actualSrc := *src
+	return sb.setDataValidation(rect, &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{
+			Type: "CUSTOM_FORMULA",
+			Values: []*sheets.ConditionValue{
+				{UserEnteredValue: formula},
+			},
+		},
+		Strict: strict,
+	}, WithInputMessage(helpText))
+}
 
-	if sb.sheetID == srcSheetID && dstR <= src.Row {
-		actualSrc.Row += src.Height
+// SetEnumDropdown adds a dropdown listing the string values of a typed enum to rect on sb.
+// If strict is true, values not in the list are rejected.
+func SetEnumDropdown[T ~string](sb *SheetBuilder, rect *Rect, values []T, strict bool, opts ...ValidationOption) *SheetBuilder {
+	strs := make([]string, len(values))
+
+	for i, v := range values {
+		strs[i] = string(v)
 	}
 
-	sb.CopyRange(srcSheetID, &actualSrc, dstR, dstC, pasteType)
+	return sb.setDropdown("SetEnumDropdown", rect, strs, strict, opts)
+}
 
-	return sb
+// setDropdown adds a ONE_OF_LIST dropdown with values to rect.
+func (sb *SheetBuilder) setDropdown(label string, rect *Rect, values []string, strict bool, opts []ValidationOption) *SheetBuilder {
+	if sb.isRectInvalid(rect, label, "rect") {
+		return sb
+	}
+
+	if len(values) == 0 {
+		sb.b.appendError(fmt.Errorf("%s: values should not be nil or empty", label))
+
+		return sb
+	}
+
+	conds := make([]*sheets.ConditionValue, len(values))
+
+	for i, v := range values {
+		conds[i] = &sheets.ConditionValue{UserEnteredValue: v}
+	}
+
+	return sb.setDataValidation(rect, &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{
+			Type:   "ONE_OF_LIST",
+			Values: conds,
+		},
+		ShowCustomUi: true,
+		Strict:       strict,
+	}, opts...)
 }
 
-// DeleteRange deletes the specified range and shifts other cells to fill the gap.
-func (sb *SheetBuilder) DeleteRange(rect *Rect, shiftDimension ShiftDimensionType) *SheetBuilder {
-	if sb.isRectInvalid(rect, "DeleteRange", "rect") {
+// SetDataValidationRaw sets the given data validation rule verbatim on the specified range.
+// A nil rule clears any existing validation.
+func (sb *SheetBuilder) SetDataValidationRaw(rect *Rect, rule *sheets.DataValidationRule) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetDataValidationRaw", "rect") {
 		return sb
 	}
 
-	if shiftDimension == "" {
-		shiftDimension = ShiftDimensionTypeRows
+	return sb.setDataValidation(rect, rule)
+}
+
+// ValidationOption customizes a data validation rule created by the validation methods.
+type ValidationOption func(rule *sheets.DataValidationRule)
+
+// WithInputMessage shows message as a prompt when a validated cell is selected.
+// An empty message is omitted.
+func WithInputMessage(message string) ValidationOption {
+	return func(rule *sheets.DataValidationRule) {
+		rule.InputMessage = message
+	}
+}
+
+// setDataValidation appends a SetDataValidation request. rect must already be validated.
+func (sb *SheetBuilder) setDataValidation(rect *Rect, rule *sheets.DataValidationRule, opts ...ValidationOption) *SheetBuilder {
+	if rule != nil {
+		for _, opt := range opts {
+			opt(rule)
+		}
 	}
 
 	req := &sheets.Request{
-		DeleteRange: &sheets.DeleteRangeRequest{
-			Range: &sheets.GridRange{
-				SheetId:          sb.sheetID,
-				StartRowIndex:    int64(rect.Row),
-				EndRowIndex:      int64(rect.Row + rect.Height),
-				StartColumnIndex: int64(rect.Col),
-				EndColumnIndex:   int64(rect.Col + rect.Width),
-			},
-			ShiftDimension: string(shiftDimension),
+		SetDataValidation: &sheets.SetDataValidationRequest{
+			Range: sb.gridRange(rect),
+			Rule:  rule,
 		},
 	}
 
@@ -758,41 +2286,56 @@ func (sb *SheetBuilder) DeleteRange(rect *Rect, shiftDimension ShiftDimensionTyp
 	return sb
 }
 
-// SetLink sets a hyperlink to the specified range.
-// url: Can be an external URL (http://...) or an internal sheet link (#gid=...).
-func (sb *SheetBuilder) SetLink(rect *Rect, url string) *SheetBuilder {
-	if sb.isRectInvalid(rect, "SetLink", "rect") {
+// StripeRows colors alternating rows of rect using conditional formatting,
+// so it does not conflict with existing banding. Rows at even offsets from the top
+// of rect (0, 2, ...) get even, the others get odd. A nil color leaves those rows unchanged.
+func (sb *SheetBuilder) StripeRows(rect *Rect, even *sheets.Color, odd *sheets.Color) *SheetBuilder {
+	if sb.isRectInvalid(rect, "StripeRows", "rect") {
 		return sb
 	}
 
-	if url == "" {
-		sb.b.appendError(errors.New("SetLink: url should not be empty"))
+	if even == nil && odd == nil {
+		sb.b.appendError(errors.New("StripeRows: even and odd should not both be nil"))
 
 		return sb
 	}
 
-	req := &sheets.Request{
-		RepeatCell: &sheets.RepeatCellRequest{
-			Range: &sheets.GridRange{
-				SheetId:          sb.sheetID,
-				StartRowIndex:    int64(rect.Row),
-				EndRowIndex:      int64(rect.Row + rect.Height),
-				StartColumnIndex: int64(rect.Col),
-				EndColumnIndex:   int64(rect.Col + rect.Width),
+	top := rect.Row + 1
+
+	if even != nil {
+		sb.addCustomFormulaRule(rect, fmt.Sprintf("=ISEVEN(ROW()-%d)", top), &sheets.CellFormat{BackgroundColor: even})
+	}
+
+	if odd != nil {
+		sb.addCustomFormulaRule(rect, fmt.Sprintf("=ISODD(ROW()-%d)", top), &sheets.CellFormat{BackgroundColor: odd})
+	}
+
+	return sb
+}
+
+// addCustomFormulaRule adds a conditional format rule that applies format where formula is true.
+// rect must already be validated.
+func (sb *SheetBuilder) addCustomFormulaRule(rect *Rect, formula string, format *sheets.CellFormat) *SheetBuilder {
+	return sb.addConditionalFormatRule(rect, &sheets.BooleanRule{
+		Condition: &sheets.BooleanCondition{
+			Type: "CUSTOM_FORMULA",
+			Values: []*sheets.ConditionValue{
+				{UserEnteredValue: formula},
 			},
-			Cell: &sheets.CellData{
-				UserEnteredFormat: &sheets.CellFormat{
-					TextFormat: &sheets.TextFormat{
-						Link: &sheets.Link{
-							Uri: url,
-						},
-						// 文字色は青、下線付きにするのが一般的ですが
-						// 既存の書式を壊さないよう、ここではリンク属性のみ設定します
-					},
-				},
+		},
+		Format: format,
+	})
+}
+
+// addConditionalFormatRule appends an AddConditionalFormatRule request for a boolean rule.
+// rect must already be validated.
+func (sb *SheetBuilder) addConditionalFormatRule(rect *Rect, rule *sheets.BooleanRule) *SheetBuilder {
+	req := &sheets.Request{
+		AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+			Rule: &sheets.ConditionalFormatRule{
+				Ranges:      []*sheets.GridRange{sb.gridRange(rect)},
+				BooleanRule: rule,
 			},
-			// リンク情報だけを更新する
-			Fields: "userEnteredFormat.textFormat.link",
 		},
 	}
 
@@ -801,101 +2344,172 @@ func (sb *SheetBuilder) SetLink(rect *Rect, url string) *SheetBuilder {
 	return sb
 }
 
-// SetLinkToSheet sets a link to jump to a specific sheet ID within the same spreadsheet.
-func (sb *SheetBuilder) SetLinkToSheet(rect *Rect, targetSheetID int64) *SheetBuilder {
-	if sb.isRectInvalid(rect, "SetLinkToSheet", "rect") {
+// InsertCheckboxColumn writes header at (headerRow, col) and turns dataCount cells
+// of col starting at dataStart into checkboxes.
+func (sb *SheetBuilder) InsertCheckboxColumn(col int, headerRow int, header string, dataStart int, dataCount int, opts ...ValidationOption) *SheetBuilder {
+	if col < 0 {
+		sb.b.appendError(fmt.Errorf("InsertCheckboxColumn: invalid col: %d", col))
+
 		return sb
 	}
 
-	if targetSheetID <= 0 {
-		sb.b.appendError(fmt.Errorf("Row: invalid target sheet id: %d", targetSheetID))
+	if headerRow < 0 {
+		sb.b.appendError(fmt.Errorf("InsertCheckboxColumn: invalid headerRow: %d", headerRow))
 
 		return sb
 	}
 
-	// スプレッドシート内部リンクの形式 (#gid=ID) を生成
-	internalLink := fmt.Sprintf("#gid=%d", targetSheetID)
+	if dataStart < 0 {
+		sb.b.appendError(fmt.Errorf("InsertCheckboxColumn: invalid dataStart: %d", dataStart))
 
-	return sb.SetLink(rect, internalLink)
+		return sb
+	}
+
+	if dataCount < 1 {
+		sb.b.appendError(fmt.Errorf("InsertCheckboxColumn: invalid dataCount: %d", dataCount))
+
+		return sb
+	}
+
+	sb.SetCellValue(headerRow, col, header)
+
+	return sb.setCheckbox(&Rect{Row: dataStart, Col: col, Height: dataCount, Width: 1}, nil, opts)
 }
 
-// SetHidden sets the visibility of the sheet.
-// true to hide, false to show.
-func (sb *SheetBuilder) SetHidden(hidden bool) *SheetBuilder {
-	req := &sheets.Request{
-		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
-			Properties: &sheets.SheetProperties{
-				SheetId: sb.sheetID,
-				Hidden:  hidden,
-			},
-			Fields: "hidden",
-		},
+// SetCheckbox turns the cells of rect into checkboxes holding TRUE/FALSE.
+func (sb *SheetBuilder) SetCheckbox(rect *Rect, opts ...ValidationOption) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetCheckbox", "rect") {
+		return sb
 	}
 
-	sb.b.AppendRequest(req)
+	return sb.setCheckbox(rect, nil, opts)
+}
 
-	return sb
+// SetCheckboxWithValues turns the cells of rect into checkboxes holding checked/unchecked
+// instead of TRUE/FALSE. If unchecked is empty, an unchecked box is an empty cell.
+func (sb *SheetBuilder) SetCheckboxWithValues(rect *Rect, checked string, unchecked string, opts ...ValidationOption) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetCheckboxWithValues", "rect") {
+		return sb
+	}
+
+	if checked == "" {
+		sb.b.appendError(errors.New("SetCheckboxWithValues: checked should not be empty"))
+
+		return sb
+	}
+
+	values := []*sheets.ConditionValue{{UserEnteredValue: checked}}
+
+	if unchecked != "" {
+		values = append(values, &sheets.ConditionValue{UserEnteredValue: unchecked})
+	}
+
+	return sb.setCheckbox(rect, values, opts)
 }
 
-// Hide hides the sheet.
-func (sb *SheetBuilder) Hide() *SheetBuilder {
-	return sb.SetHidden(true)
+// setCheckbox sets a BOOLEAN validation rule with the given custom values. rect must already be validated.
+func (sb *SheetBuilder) setCheckbox(rect *Rect, values []*sheets.ConditionValue, opts []ValidationOption) *SheetBuilder {
+	return sb.setDataValidation(rect, &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{
+			Type:   "BOOLEAN",
+			Values: values,
+		},
+	}, opts...)
 }
 
-// Show shows the sheet.
-func (sb *SheetBuilder) Show() *SheetBuilder {
-	return sb.SetHidden(false)
+// FormatWhenChecked applies format to each row of rect whose checkbox in checkboxCol is checked.
+func (sb *SheetBuilder) FormatWhenChecked(checkboxCol int, rect *Rect, format *sheets.CellFormat) *SheetBuilder {
+	if checkboxCol < 0 {
+		sb.b.appendError(fmt.Errorf("FormatWhenChecked: invalid checkboxCol: %d", checkboxCol))
+
+		return sb
+	}
+
+	if sb.isRectInvalid(rect, "FormatWhenChecked", "rect") {
+		return sb
+	}
+
+	if format == nil {
+		sb.b.appendError(errors.New("FormatWhenChecked: format should not be nil"))
+
+		return sb
+	}
+
+	// 列だけ絶対参照にして、行ごとに相対的に評価させる (例: =$C2=TRUE)
+	ref, err := IndexToA1AtAbs(rect.Row, checkboxCol, AbsCol)
+	if err != nil {
+		sb.b.appendError(fmt.Errorf("FormatWhenChecked: %w", err))
+
+		return sb
+	}
+
+	return sb.addCustomFormulaRule(rect, "="+ref+"=TRUE", format)
 }
 
-// SetColumnsHidden sets the visibility of columns.
-func (sb *SheetBuilder) SetColumnsHidden(startCol int, count int, hidden bool) *SheetBuilder {
-	if startCol <= 0 {
-		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid start column: %d", startCol))
+// HighlightByFormula sets the background of cells in rect where customFormula is true.
+// The formula is evaluated relative to the top-left cell of rect, so "=$E2>100"
+// on a rect starting at row 2 highlights whole rows based on column E.
+func (sb *SheetBuilder) HighlightByFormula(rect *Rect, customFormula string, bg *sheets.Color) *SheetBuilder {
+	if sb.isRectInvalid(rect, "HighlightByFormula", "rect") {
+		return sb
+	}
+
+	if customFormula == "" {
+		sb.b.appendError(errors.New("HighlightByFormula: customFormula should not be empty"))
 
 		return sb
 	}
 
-	if count <= 0 {
-		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid count: %d", count))
+	if bg == nil {
+		sb.b.appendError(errors.New("HighlightByFormula: bg should not be nil"))
 
 		return sb
 	}
 
-	return sb.updateDimension("COLUMNS", startCol, count, &sheets.DimensionProperties{HiddenByUser: hidden}, "hiddenByUser")
+	if !strings.HasPrefix(customFormula, "=") {
+		customFormula = "=" + customFormula
+	}
+
+	return sb.addCustomFormulaRule(rect, customFormula, &sheets.CellFormat{BackgroundColor: bg})
 }
 
-// SetRowsHidden sets the visibility of rows.
-func (sb *SheetBuilder) SetRowsHidden(startRow int, count int, hidden bool) *SheetBuilder {
-	if startRow <= 0 {
-		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid start row: %d", startRow))
+// MoveChart moves an embedded chart (or other embedded object) so that it is anchored
+// at (anchorRow, anchorCol) on this sheet, offset by offsetX/offsetY pixels.
+// Offsets may be negative to shift the object up or left of the anchor cell.
+func (sb *SheetBuilder) MoveChart(objectID int64, anchorRow int, anchorCol int, offsetX int, offsetY int) *SheetBuilder {
+	if objectID < 0 {
+		sb.b.appendError(fmt.Errorf("MoveChart: invalid object id: %d", objectID))
 
 		return sb
 	}
 
-	if count <= 0 {
-		sb.b.appendError(fmt.Errorf("SetColumnsHidden: invalid count: %d", count))
+	if anchorRow < 0 {
+		sb.b.appendError(fmt.Errorf("MoveChart: invalid anchor row: %d", anchorRow))
 
 		return sb
 	}
 
-	sb.updateDimension("ROWS", startRow, count, &sheets.DimensionProperties{HiddenByUser: hidden}, "hiddenByUser")
+	if anchorCol < 0 {
+		sb.b.appendError(fmt.Errorf("MoveChart: invalid anchor col: %d", anchorCol))
 
-	return sb
-}
+		return sb
+	}
 
-// updateDimension is a generic helper to update row or column properties.
-// It handles the tedious API request construction.
-func (sb *SheetBuilder) updateDimension(dimension string, start int, count int, props *sheets.DimensionProperties, fields string) *SheetBuilder {
 	req := &sheets.Request{
-		UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
-			Range: &sheets.DimensionRange{
-				SheetId:    sb.sheetID,
-				Dimension:  dimension,
-				StartIndex: int64(start),
-				EndIndex:   int64(start + count),
+		UpdateEmbeddedObjectPosition: &sheets.UpdateEmbeddedObjectPositionRequest{
+			ObjectId: objectID,
+			NewPosition: &sheets.EmbeddedObjectPosition{
+				OverlayPosition: &sheets.OverlayPosition{
+					AnchorCell: &sheets.GridCoordinate{
+						SheetId:     sb.sheetID,
+						RowIndex:    int64(anchorRow),
+						ColumnIndex: int64(anchorCol),
+					},
+					OffsetXPixels: int64(offsetX),
+					OffsetYPixels: int64(offsetY),
+				},
 			},
-			Properties: props,
-			Fields:     fields,
+			Fields: "anchorCell,offsetXPixels,offsetYPixels",
 		},
 	}
 
@@ -904,48 +2518,94 @@ func (sb *SheetBuilder) updateDimension(dimension string, start int, count int,
 	return sb
 }
 
-// RangeUnset represents a value indicating that the range parameter
-const rangeUnset = -1
+// EnforceNumberRange rejects numbers outside [minValue, maxValue] in rect and,
+// since validation can be bypassed by pasting, highlights out-of-range numbers with invalidBg.
+func (sb *SheetBuilder) EnforceNumberRange(rect *Rect, minValue float64, maxValue float64, invalidBg *sheets.Color) *SheetBuilder {
+	if sb.isRectInvalid(rect, "EnforceNumberRange", "rect") {
+		return sb
+	}
 
-// clearValues creates a request to clear values.
-func (sb *SheetBuilder) clearValues(row int, col int, height int, width int) *SheetBuilder {
-	rng := &sheets.GridRange{
-		SheetId: sb.sheetID,
+	if minValue > maxValue {
+		sb.b.appendError(fmt.Errorf("EnforceNumberRange: min %v is greater than max %v", minValue, maxValue))
+
+		return sb
 	}
 
-	if row >= 0 {
-		rng.StartRowIndex = int64(row)
+	if invalidBg == nil {
+		sb.b.appendError(errors.New("EnforceNumberRange: invalidBg should not be nil"))
+
+		return sb
 	}
 
-	if col >= 0 {
-		rng.StartColumnIndex = int64(col)
+	bounds := []*sheets.ConditionValue{
+		{UserEnteredValue: strconv.FormatFloat(minValue, 'f', -1, 64)},
+		{UserEnteredValue: strconv.FormatFloat(maxValue, 'f', -1, 64)},
 	}
 
-	if height > 0 {
-		start := int64(0)
+	sb.setDataValidation(rect, &sheets.DataValidationRule{
+		Condition: &sheets.BooleanCondition{
+			Type:   "NUMBER_BETWEEN",
+			Values: bounds,
+		},
+		Strict: true,
+	})
 
-		if row > 0 {
-			start = int64(row)
-		}
+	return sb.addConditionalFormatRule(rect, &sheets.BooleanRule{
+		Condition: &sheets.BooleanCondition{
+			Type:   "NUMBER_NOT_BETWEEN",
+			Values: bounds,
+		},
+		Format: &sheets.CellFormat{BackgroundColor: invalidBg},
+	})
+}
 
-		rng.EndRowIndex = start + int64(height)
+// AddSumRow writes =SUM(...) formulas for each column of dataRect into the row just below it.
+// If bold is true, the totals row is also made bold.
+func (sb *SheetBuilder) AddSumRow(dataRect *Rect, bold bool) *SheetBuilder {
+	if sb.isRectInvalid(dataRect, "AddSumRow", "dataRect") {
+		return sb
 	}
 
-	if width > 0 {
-		start := int64(0)
+	if dataRect.Height < 1 || dataRect.Width < 1 {
+		sb.b.appendError(fmt.Errorf("AddSumRow: dataRect should be bounded and non-empty: %dx%d", dataRect.Height, dataRect.Width))
 
-		if col > 0 {
-			start = int64(col)
+		return sb
+	}
+
+	cells := make([]*sheets.CellData, dataRect.Width)
+
+	for i := range cells {
+		a1, err := rectToA1(&Rect{Row: dataRect.Row, Col: dataRect.Col + i, Height: dataRect.Height, Width: 1})
+		if err != nil {
+			sb.b.appendError(fmt.Errorf("AddSumRow: %w", err))
+
+			return sb
 		}
 
-		rng.EndColumnIndex = start + int64(width)
+		cells[i] = formulaCell("SUM(" + a1 + ")")
+
+		if bold {
+			cells[i].UserEnteredFormat = &sheets.CellFormat{
+				TextFormat: &sheets.TextFormat{Bold: true},
+			}
+		}
+	}
+
+	fields := "userEnteredValue"
+
+	if bold {
+		fields += ",userEnteredFormat.textFormat.bold"
 	}
 
 	req := &sheets.Request{
-		RepeatCell: &sheets.RepeatCellRequest{
-			Range:  rng,
-			Cell:   &sheets.CellData{},
-			Fields: "userEnteredValue", // Only clear values
+		UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:     sb.sheetID,
+				RowIndex:    int64(dataRect.Row + dataRect.Height),
+				ColumnIndex: int64(dataRect.Col),
+			},
+			Rows:   []*sheets.RowData{{Values: cells}},
+			Fields: fields,
 		},
 	}
 
@@ -954,138 +2614,206 @@ func (sb *SheetBuilder) clearValues(row int, col int, height int, width int) *Sh
 	return sb
 }
 
-// ClearAllValues clears all values in the sheet.
-func (sb *SheetBuilder) ClearAllValues() *SheetBuilder {
-	return sb.clearValues(rangeUnset, rangeUnset, rangeUnset, rangeUnset)
+// SetColumnAsDate applies a DATE number format with pattern (e.g. "yyyy-mm-dd") to column col,
+// from row skipRows down to the end of the sheet. An empty pattern uses the locale default.
+func (sb *SheetBuilder) SetColumnAsDate(col int, skipRows int, pattern string) *SheetBuilder {
+	return sb.setColumnFormat("SetColumnAsDate", col, skipRows, NumberFormatTypeDate, pattern)
 }
 
-// ClearRangeValues clears values in the specified rectangle.
-func (sb *SheetBuilder) ClearRangeValues(rect *Rect) *SheetBuilder {
-	if sb.isRectInvalid(rect, "ClearRangeValues", "rect") {
-		return sb
-	}
+// SetColumnAsNumber applies a NUMBER format with pattern (e.g. "#,##0.00") to column col,
+// from row skipRows down to the end of the sheet. An empty pattern uses the locale default.
+func (sb *SheetBuilder) SetColumnAsNumber(col int, skipRows int, pattern string) *SheetBuilder {
+	return sb.setColumnFormat("SetColumnAsNumber", col, skipRows, NumberFormatTypeNumber, pattern)
+}
 
-	return sb.clearValues(rect.Row, rect.Col, rect.Height, rect.Width)
+// SetColumnAsText applies a TEXT format to column col, from row skipRows down to the end of the sheet,
+// so that entered values are not parsed as numbers or dates.
+func (sb *SheetBuilder) SetColumnAsText(col int, skipRows int) *SheetBuilder {
+	return sb.setColumnFormat("SetColumnAsText", col, skipRows, NumberFormatTypeText, "")
 }
 
-// ClearColValues clears values in the specified columns.
-func (sb *SheetBuilder) ClearColValues(col int, width int, skipRows int) *SheetBuilder {
+// setColumnFormat applies a number format to an open-ended column range.
+func (sb *SheetBuilder) setColumnFormat(label string, col int, skipRows int, formatType NumberFormatType, pattern string) *SheetBuilder {
 	if col < 0 {
-		sb.b.appendError(fmt.Errorf("ClearColValues: invalid col: %d", col))
+		sb.b.appendError(fmt.Errorf("%s: invalid col: %d", label, col))
 
 		return sb
 	}
 
-	if width < 1 {
-		sb.b.appendError(fmt.Errorf("ClearColValues: invalid width: %d", width))
+	if skipRows < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid skipRows: %d", label, skipRows))
 
 		return sb
 	}
 
-	if skipRows < 0 {
-		sb.b.appendError(fmt.Errorf("ClearColValues: invalid skipRows: %d", skipRows))
+	return sb.setNumberFormat(&Rect{Row: skipRows, Col: col, Height: rangeUnset, Width: 1}, formatType, pattern)
+}
 
+// SetNumberFormat sets how numbers in rect are displayed.
+// pattern is optional (e.g. "#,##0.00" or "yyyy-mm-dd"); if empty, the locale default for formatType is used.
+func (sb *SheetBuilder) SetNumberFormat(rect *Rect, formatType NumberFormatType, pattern string) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetNumberFormat", "rect") {
 		return sb
 	}
 
-	return sb.clearValues(skipRows, col, rangeUnset, width)
+	if formatType == "" {
+		sb.b.appendError(errors.New("SetNumberFormat: formatType should not be empty"))
+
+		return sb
+	}
+
+	return sb.setNumberFormat(rect, formatType, pattern)
 }
 
-// ClearRowValues clears values in the specified rows.
-func (sb *SheetBuilder) ClearRowValues(row int, height int, skipCols int) *SheetBuilder {
-	if row < 0 {
-		sb.b.appendError(fmt.Errorf("ClearRowValues: invalid row: %d", row))
+// setNumberFormat appends a RepeatCell request setting the number format. rect must already be validated.
+func (sb *SheetBuilder) setNumberFormat(rect *Rect, formatType NumberFormatType, pattern string) *SheetBuilder {
+	cell := &sheets.CellData{
+		UserEnteredFormat: &sheets.CellFormat{
+			NumberFormat: &sheets.NumberFormat{
+				Type:    string(formatType),
+				Pattern: pattern,
+			},
+		},
+	}
+
+	return sb.repeatCell(rect, cell, "userEnteredFormat.numberFormat")
+}
 
+// SetupHeader writes values into the first row of rect and colors the whole rect with bg and fg
+// (a nil color is left unchanged). If freeze is true, rows down to the bottom of rect are frozen.
+// If protect is true, rect is protected so that only the owner can edit it, with the description
+// "header"; call ProtectRange instead for another description or editors.
+// Errors from the individual steps are reported with the SetupHeader prefix.
+func (sb *SheetBuilder) SetupHeader(rect *Rect, values []any, bg *sheets.Color, fg *sheets.Color, freeze bool, protect bool) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SetupHeader", "rect") {
 		return sb
 	}
 
-	if height < 1 {
-		sb.b.appendError(fmt.Errorf("ClearRowValues: invalid height: %d", height))
+	if rect.Height < 1 || rect.Width < 1 {
+		sb.b.appendError(fmt.Errorf("SetupHeader: rect should be bounded and non-empty: %dx%d", rect.Height, rect.Width))
 
 		return sb
 	}
 
-	if skipCols < 0 {
-		sb.b.appendError(fmt.Errorf("ClearRowValues: invalid skipCols: %d", skipCols))
+	if len(values) > rect.Width {
+		sb.b.appendError(fmt.Errorf("SetupHeader: %d values exceed rect width %d", len(values), rect.Width))
 
 		return sb
 	}
 
-	return sb.clearValues(row, skipCols, height, rangeUnset)
-}
+	// 各ステップのエラーも SetupHeader のものとして報告する
+	defer sb.b.wrapErrorsSince(len(sb.b.errs), "SetupHeader")
 
-func (sb *SheetBuilder) isRectInvalid(rect *Rect, label string, name string) bool {
-	if rect == nil {
-		sb.b.appendError(fmt.Errorf("%s: %s should not be nil", label, name))
+	if len(values) > 0 {
+		sb.SetRowValues(rect.Row, rect.Col, values)
+	}
 
-		return true
+	if bg != nil {
+		sb.SetBackgroundColor(rect, bg)
 	}
 
-	if rect.Row < 0 {
-		sb.b.appendError(fmt.Errorf("%s: invalid %s.Row: %d", label, name, rect.Row))
+	if fg != nil {
+		sb.SetForegroundColor(rect, fg)
+	}
 
-		return true
+	if freeze {
+		sb.FreezeRows(rect.Row + rect.Height)
 	}
 
-	if rect.Col < 0 {
-		sb.b.appendError(fmt.Errorf("%s: invalid %s.Col: %d", label, name, rect.Col))
+	if protect {
+		sb.ProtectRange(rect, "header", nil, false)
+	}
 
-		return true
+	return sb
+}
+
+// AddDataBar shades numbers in rect by magnitude, from white at minValue to color at maxValue.
+// The Sheets API has no native data bar, so this is approximated with a gradient
+// (color scale) conditional format: the whole cell is tinted rather than a bar being drawn.
+func (sb *SheetBuilder) AddDataBar(rect *Rect, color *sheets.Color, minValue float64, maxValue float64) *SheetBuilder {
+	if sb.isRectInvalid(rect, "AddDataBar", "rect") {
+		return sb
 	}
 
-	if rect.Height < 0 && rect.Height != rangeUnset {
-		sb.b.appendError(fmt.Errorf("%s: invalid %s.Height: %d", label, name, rect.Height))
+	if color == nil {
+		sb.b.appendError(errors.New("AddDataBar: color should not be nil"))
 
-		return true
+		return sb
 	}
 
-	if rect.Width < 0 && rect.Width != rangeUnset {
-		sb.b.appendError(fmt.Errorf("%s: invalid %s.Width: %d", label, name, rect.Width))
+	if minValue > maxValue {
+		sb.b.appendError(fmt.Errorf("AddDataBar: min %v is greater than max %v", minValue, maxValue))
 
-		return true
+		return sb
 	}
 
-	return false
-}
+	req := &sheets.Request{
+		AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+			Rule: &sheets.ConditionalFormatRule{
+				Ranges: []*sheets.GridRange{sb.gridRange(rect)},
+				GradientRule: &sheets.GradientRule{
+					Minpoint: &sheets.InterpolationPoint{
+						Color: &sheets.Color{Red: 1.0, Green: 1.0, Blue: 1.0},
+						Type:  "NUMBER",
+						Value: strconv.FormatFloat(minValue, 'f', -1, 64),
+					},
+					Maxpoint: &sheets.InterpolationPoint{
+						Color: color,
+						Type:  "NUMBER",
+						Value: strconv.FormatFloat(maxValue, 'f', -1, 64),
+					},
+				},
+			},
+		},
+	}
 
-// freeze creates a request to freeze rows and/or columns.
-// If a parameter is rangeUnset, it is ignored (not updated).
-func (sb *SheetBuilder) freeze(rows int, cols int) *SheetBuilder {
-	gridProps := &sheets.GridProperties{}
+	sb.b.AppendRequest(req)
 
-	var fields []string
+	return sb
+}
 
-	if rows < rangeUnset {
-		sb.b.appendError(fmt.Errorf("freeze: invalid rows: %d", rows))
+// GroupRows groups count rows starting at startRow so they can be collapsed.
+func (sb *SheetBuilder) GroupRows(startRow int, count int) *SheetBuilder {
+	return sb.addDimensionGroup("GroupRows", "ROWS", startRow, count)
+}
 
-		return sb
-	} else if rows > rangeUnset {
-		gridProps.FrozenRowCount = int64(rows)
+// GroupColumns groups count columns starting at startCol so they can be collapsed.
+func (sb *SheetBuilder) GroupColumns(startCol int, count int) *SheetBuilder {
+	return sb.addDimensionGroup("GroupColumns", "COLUMNS", startCol, count)
+}
 
-		fields = append(fields, "gridProperties.frozenRowCount")
-	}
+// UngroupRows removes one level of grouping from count rows starting at startRow.
+func (sb *SheetBuilder) UngroupRows(startRow int, count int) *SheetBuilder {
+	return sb.deleteDimensionGroup("UngroupRows", "ROWS", startRow, count)
+}
 
-	if cols < rangeUnset {
-		sb.b.appendError(fmt.Errorf("freeze: invalid cols: %d", cols))
+// UngroupColumns removes one level of grouping from count columns starting at startCol.
+func (sb *SheetBuilder) UngroupColumns(startCol int, count int) *SheetBuilder {
+	return sb.deleteDimensionGroup("UngroupColumns", "COLUMNS", startCol, count)
+}
 
-		return sb
-	} else if cols > rangeUnset {
-		gridProps.FrozenColumnCount = int64(cols)
+// SetGroupCollapsed collapses or expands the existing group covering exactly count rows or columns
+// starting at start. dimension is "ROWS" or "COLUMNS".
+func (sb *SheetBuilder) SetGroupCollapsed(dimension string, start int, count int, collapsed bool) *SheetBuilder {
+	if dimension != "ROWS" && dimension != "COLUMNS" {
+		sb.b.appendError(fmt.Errorf("SetGroupCollapsed: invalid dimension: %q", dimension))
 
-		fields = append(fields, "gridProperties.frozenColumnCount")
+		return sb
 	}
 
-	if len(fields) == 0 {
+	rng, ok := sb.dimensionGroupRange("SetGroupCollapsed", dimension, start, count)
+	if !ok {
 		return sb
 	}
 
 	req := &sheets.Request{
-		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
-			Properties: &sheets.SheetProperties{
-				SheetId:        sb.sheetID,
-				GridProperties: gridProps,
+		UpdateDimensionGroup: &sheets.UpdateDimensionGroupRequest{
+			DimensionGroup: &sheets.DimensionGroup{
+				Range:           rng,
+				Collapsed:       collapsed,
+				ForceSendFields: []string{"Collapsed"},
 			},
-			Fields: strings.Join(fields, ","),
+			Fields: "collapsed",
 		},
 	}
 
@@ -1094,21 +2822,98 @@ func (sb *SheetBuilder) freeze(rows int, cols int) *SheetBuilder {
 	return sb
 }
 
-// Freeze freezes the specified number of rows and columns.
-// To leave a dimension unchanged, pass rangeUnset (or use FreezeRows/FreezeCols).
-// Passing 0 unfreezes the dimension.
-func (sb *SheetBuilder) Freeze(rows int, cols int) *SheetBuilder {
-	return sb.freeze(rows, cols)
+// addDimensionGroup appends an AddDimensionGroup request.
+func (sb *SheetBuilder) addDimensionGroup(label string, dimension string, start int, count int) *SheetBuilder {
+	rng, ok := sb.dimensionGroupRange(label, dimension, start, count)
+	if !ok {
+		return sb
+	}
+
+	sb.b.AppendRequest(&sheets.Request{
+		AddDimensionGroup: &sheets.AddDimensionGroupRequest{Range: rng},
+	})
+
+	return sb
 }
 
-// FreezeRows freezes the first n rows.
-// Pass 0 to unfreeze rows. Columns remain unchanged.
-func (sb *SheetBuilder) FreezeRows(rows int) *SheetBuilder {
-	return sb.freeze(rows, rangeUnset)
+// deleteDimensionGroup appends a DeleteDimensionGroup request.
+func (sb *SheetBuilder) deleteDimensionGroup(label string, dimension string, start int, count int) *SheetBuilder {
+	rng, ok := sb.dimensionGroupRange(label, dimension, start, count)
+	if !ok {
+		return sb
+	}
+
+	sb.b.AppendRequest(&sheets.Request{
+		DeleteDimensionGroup: &sheets.DeleteDimensionGroupRequest{Range: rng},
+	})
+
+	return sb
 }
 
-// FreezeCols freezes the first n columns.
-// Pass 0 to unfreeze columns. Rows remain unchanged.
-func (sb *SheetBuilder) FreezeCols(cols int) *SheetBuilder {
-	return sb.freeze(rangeUnset, cols)
+// dimensionGroupRange validates start and count and returns the DimensionRange.
+func (sb *SheetBuilder) dimensionGroupRange(label string, dimension string, start int, count int) (*sheets.DimensionRange, bool) {
+	if start < 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid start: %d", label, start))
+
+		return nil, false
+	}
+
+	if count <= 0 {
+		sb.b.appendError(fmt.Errorf("%s: invalid count: %d", label, count))
+
+		return nil, false
+	}
+
+	return &sheets.DimensionRange{
+		SheetId:    sb.sheetID,
+		Dimension:  dimension,
+		StartIndex: int64(start),
+		EndIndex:   int64(start + count),
+	}, true
+}
+
+// SortRange sorts the rows of rect by specs, the first spec being the primary key.
+func (sb *SheetBuilder) SortRange(rect *Rect, specs ...SortSpec) *SheetBuilder {
+	if sb.isRectInvalid(rect, "SortRange", "rect") {
+		return sb
+	}
+
+	if len(specs) == 0 {
+		sb.b.appendError(errors.New("SortRange: specs should not be empty"))
+
+		return sb
+	}
+
+	sortSpecs := make([]*sheets.SortSpec, 0, len(specs))
+
+	for i, spec := range specs {
+		if spec.ColumnOffset < 0 || (rect.Width != rangeUnset && spec.ColumnOffset >= rect.Width) {
+			sb.b.appendError(fmt.Errorf("SortRange: invalid specs[%d].ColumnOffset: %d", i, spec.ColumnOffset))
+
+			return sb
+		}
+
+		order := "DESCENDING"
+
+		if spec.Ascending {
+			order = "ASCENDING"
+		}
+
+		sortSpecs = append(sortSpecs, &sheets.SortSpec{
+			DimensionIndex:  int64(rect.Col + spec.ColumnOffset),
+			SortOrder:       order,
+			ForceSendFields: []string{"DimensionIndex"},
+		})
+	}
+
+	req := &sheets.Request{
+		SortRange: &sheets.SortRangeRequest{
+			Range:     sb.gridRange(rect),
+			SortSpecs: sortSpecs,
+		},
+	}
+
+	sb.b.AppendRequest(req)
+
+	return sb
 }
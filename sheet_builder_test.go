@@ -0,0 +1,291 @@
+package haresheet
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// generalRows builds rows through toCellData, i.e. the path SetRangeValues falls back to.
+func generalRows(sb *SheetBuilder, values [][]any) []*sheets.RowData {
+	rows := make([]*sheets.RowData, 0, len(values))
+
+	for _, rowVals := range values {
+		cells := make([]*sheets.CellData, 0, len(rowVals))
+
+		for _, v := range rowVals {
+			cells = append(cells, sb.toCellData(v, "SetRangeValues"))
+		}
+
+		rows = append(rows, &sheets.RowData{Values: cells})
+	}
+
+	return rows
+}
+
+func TestHomogeneousRowsMatchesGeneralPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		values [][]any
+		fast   bool
+	}{
+		{"strings and formulas", [][]any{{"a", "=B1"}, {"c"}}, true},
+		{"float64", [][]any{{1.5, 2.0}, {3.25, 4.0}}, true},
+		{"int", [][]any{{1, 2, 3}, {4, 5, 6}}, true},
+		{"mixed int kinds", [][]any{{int8(1), int64(2)}, {uint(3), float32(4)}}, true},
+		{"number then string", [][]any{{1, "x"}}, false},
+		{"string then number", [][]any{{"x"}, {1}}, false},
+		{"bool first", [][]any{{true, 1}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := NewBuilder().Sheet(0)
+
+			got, ok := sb.homogeneousRows(tt.values)
+			if ok != tt.fast {
+				t.Fatalf("homogeneousRows() ok = %v, want %v", ok, tt.fast)
+			}
+
+			if !ok {
+				return
+			}
+
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(generalRows(sb, tt.values))
+
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("homogeneousRows() = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+// benchMatrix returns a 1000x100 (100k-cell) matrix filled by fn.
+func benchMatrix(fn func(r, c int) any) [][]any {
+	m := make([][]any, 1000)
+
+	for r := range m {
+		m[r] = make([]any, 100)
+
+		for c := range m[r] {
+			m[r][c] = fn(r, c)
+		}
+	}
+
+	return m
+}
+
+func BenchmarkSetRangeValues(b *testing.B) {
+	floats := benchMatrix(func(r, c int) any { return float64(r * c) })
+	ints := benchMatrix(func(r, c int) any { return r * c })
+	strs := benchMatrix(func(r, c int) any { return "cell" })
+
+	// 最後のセルだけ型が違うので、型チェックを最後まで進めてから一般パスに落ちる (最悪ケース)
+	general := benchMatrix(func(r, c int) any { return float64(r * c) })
+	last := general[len(general)-1]
+	last[len(last)-1] = true
+
+	cases := []struct {
+		name   string
+		values [][]any
+	}{
+		{"float64", floats},
+		{"int", ints},
+		{"string", strs},
+		{"general", general},
+	}
+
+	for _, bc := range cases {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				NewBuilder().Sheet(0).SetRangeValues(0, 0, bc.values)
+			}
+		})
+	}
+}
+
+func TestSetRangeValuesSkipEmptyRequestCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		values [][]any
+		want   int
+	}{
+		{
+			name:   "empty middle column",
+			values: [][]any{{"a", "", "b"}, {"c", nil, "d"}, {"e", "", "f"}},
+			want:   2,
+		},
+		{
+			name:   "full block",
+			values: [][]any{{1, 2}, {3, 4}},
+			want:   1,
+		},
+		{
+			name:   "span changes",
+			values: [][]any{{"a", "b"}, {"c", ""}, {"d", ""}, {"e", "f"}},
+			want:   3,
+		},
+		{
+			name:   "blank row splits block",
+			values: [][]any{{"a"}, {""}, {"b"}},
+			want:   2,
+		},
+		{
+			name:   "all empty",
+			values: [][]any{{"", nil}},
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		b := NewBuilder().SkipEmpty(true)
+		b.Sheet(0).SetRangeValues(1, 1, tt.values)
+
+		reqs, err := b.Requests()
+		if err != nil {
+			t.Fatalf("%s: Requests() error = %v", tt.name, err)
+		}
+
+		if len(reqs) != tt.want {
+			t.Errorf("%s: len(Requests()) = %d, want %d", tt.name, len(reqs), tt.want)
+		}
+	}
+}
+
+func TestSetRangeValuesSkipEmptyLayout(t *testing.T) {
+	b := NewBuilder().SkipEmpty(true)
+	b.Sheet(0).SetRangeValues(1, 1, [][]any{{"a", "", "b"}, {"c", "", "d"}})
+
+	reqs, err := b.Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	if len(reqs) != 2 {
+		t.Fatalf("len(Requests()) = %d, want 2", len(reqs))
+	}
+
+	for i, wantCol := range []int64{1, 3} {
+		uc := reqs[i].UpdateCells
+		if uc.Start.RowIndex != 1 || uc.Start.ColumnIndex != wantCol {
+			t.Errorf("Requests()[%d] start = (%d, %d), want (1, %d)", i, uc.Start.RowIndex, uc.Start.ColumnIndex, wantCol)
+		}
+
+		if len(uc.Rows) != 2 || len(uc.Rows[0].Values) != 1 {
+			t.Errorf("Requests()[%d] has %d rows, want 2 rows of 1 cell", i, len(uc.Rows))
+		}
+	}
+}
+
+func TestUnsupportedValueErrorLabel(t *testing.T) {
+	type unsupported struct{}
+
+	tests := []struct {
+		name string
+		set  func(sb *SheetBuilder)
+	}{
+		{"SetCellValue", func(sb *SheetBuilder) { sb.SetCellValue(0, 0, unsupported{}) }},
+		{"SetRowValues", func(sb *SheetBuilder) { sb.SetRowValues(0, 0, []any{unsupported{}}) }},
+		{"SetColValues", func(sb *SheetBuilder) { sb.SetColValues(0, 0, []any{unsupported{}}) }},
+		{"SetRangeValues", func(sb *SheetBuilder) { sb.SetRangeValues(0, 0, [][]any{{unsupported{}}}) }},
+		{"SetRangeValuesByColumn", func(sb *SheetBuilder) { sb.SetRangeValuesByColumn(0, 0, [][]any{{unsupported{}}}) }},
+	}
+
+	for _, tt := range tests {
+		for _, skipEmpty := range []bool{false, true} {
+			b := NewBuilder().SkipEmpty(skipEmpty)
+			tt.set(b.Sheet(0))
+
+			_, err := b.Requests()
+			if err == nil || !strings.HasPrefix(err.Error(), tt.name+": unsupported value type") {
+				t.Errorf("%s (skipEmpty=%v): err = %v, want %q prefix", tt.name, skipEmpty, err, tt.name+": unsupported value type")
+			}
+		}
+	}
+}
+
+func TestSetRangeValuesByColumnRagged(t *testing.T) {
+	b := NewBuilder()
+	b.Sheet(0).SetRangeValuesByColumn(1, 0, [][]any{{"a", "b", "c"}, {"x"}})
+
+	reqs, err := b.Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	if len(reqs) != 2 {
+		t.Fatalf("len(Requests()) = %d, want 2", len(reqs))
+	}
+
+	tests := []struct {
+		col  int64
+		want [][]string
+	}{
+		{0, [][]string{{"a"}, {"b"}, {"c"}}},
+		{1, [][]string{{"x"}}},
+	}
+
+	for i, tt := range tests {
+		uc := reqs[i].UpdateCells
+
+		if uc.Start.RowIndex != 1 || uc.Start.ColumnIndex != tt.col {
+			t.Errorf("Requests()[%d] start = (%d, %d), want (1, %d)", i, uc.Start.RowIndex, uc.Start.ColumnIndex, tt.col)
+		}
+
+		if len(uc.Rows) != len(tt.want) {
+			t.Fatalf("Requests()[%d] has %d rows, want %d", i, len(uc.Rows), len(tt.want))
+		}
+
+		for r, rowData := range uc.Rows {
+			if len(rowData.Values) != len(tt.want[r]) {
+				t.Fatalf("Requests()[%d] row %d has %d cells, want %d", i, r, len(rowData.Values), len(tt.want[r]))
+			}
+
+			for c, cell := range rowData.Values {
+				if got := extendedValue(cell); got != tt.want[r][c] {
+					t.Errorf("Requests()[%d] cell (%d, %d) = %v, want %q", i, r, c, got, tt.want[r][c])
+				}
+			}
+		}
+	}
+}
+
+func TestSetRangeValuesByColumnSameLength(t *testing.T) {
+	b := NewBuilder()
+	b.Sheet(0).SetRangeValuesByColumn(0, 0, [][]any{{"a", "b"}, {"c", "d"}, {}, {"e"}})
+
+	reqs, err := b.Requests()
+	if err != nil {
+		t.Fatalf("Requests() error = %v", err)
+	}
+
+	// 同じ長さの先頭 2 列で 1 つ、空の列は書かず、最後の列で 1 つ
+	if len(reqs) != 2 {
+		t.Fatalf("len(Requests()) = %d, want 2", len(reqs))
+	}
+
+	if got := reqs[1].UpdateCells.Start.ColumnIndex; got != 3 {
+		t.Errorf("Requests()[1] column = %d, want 3", got)
+	}
+}
+
+func TestSetupHeaderErrorPrefix(t *testing.T) {
+	type unsupported struct{}
+
+	b := NewBuilder()
+	b.Sheet(0).SetupHeader(&Rect{Row: 0, Col: 0, Height: 1, Width: 2}, []any{"a", unsupported{}}, nil, nil, false, false)
+
+	_, err := b.Requests()
+	if err == nil {
+		t.Fatal("Requests() error = nil, want an unsupported value error")
+	}
+
+	if want := "SetupHeader: SetRowValues: unsupported value type"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("Requests() error = %q, want %q prefix", err, want)
+	}
+}
@@ -2,8 +2,14 @@ package haresheet
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
 
+	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/sheets/v4"
 )
 
@@ -11,49 +17,62 @@ type SheetClient struct {
 	c       *Client
 	sheetID int64
 	err     error
+
+	valueRender    ValueRenderOption
+	dateTimeRender DateTimeRenderOption
 }
 
-func (sc *SheetClient) getValues(ctx context.Context, row int, col int, height int, width int) ([][]any, error) {
-	rng := &sheets.GridRange{
-		SheetId:          sc.sheetID,
-		StartRowIndex:    int64(row),
-		StartColumnIndex: int64(col),
-	}
+// WithValueRenderOption sets how values are rendered by subsequent reads.
+func (sc *SheetClient) WithValueRenderOption(opt ValueRenderOption) *SheetClient {
+	sc.valueRender = opt
 
-	if height > 0 {
-		rng.EndRowIndex = int64(row + height)
-	}
+	return sc
+}
 
-	if width > 0 {
-		rng.EndColumnIndex = int64(col + width)
-	}
+// WithDateTimeRenderOption sets how dates and times are rendered by subsequent reads.
+func (sc *SheetClient) WithDateTimeRenderOption(opt DateTimeRenderOption) *SheetClient {
+	sc.dateTimeRender = opt
 
-	req := &sheets.BatchGetValuesByDataFilterRequest{
-		DataFilters: []*sheets.DataFilter{
-			{GridRange: rng},
-		},
-	}
+	return sc
+}
+
+func (sc *SheetClient) getValues(ctx context.Context, row int, col int, height int, width int) ([][]any, error) {
+	return sc.getValuesByDimension(ctx, row, col, height, width, "ROWS")
+}
 
-	resp, err := sc.c.service.Spreadsheets.Values.BatchGetByDataFilter(sc.c.spreadID, req).Context(ctx).Do()
+// getValuesByDimension reads and shapes values. If major is "COLUMNS",
+// the outer slice is indexed by column and the inner slice by row.
+func (sc *SheetClient) getValuesByDimension(ctx context.Context, row int, col int, height int, width int, major string) ([][]any, error) {
+	vr, err := sc.getValueRange(ctx, row, col, height, width, major)
 	if err != nil {
 		return nil, err
 	}
 
+	outer, inner := height, width
+
+	if major == "COLUMNS" {
+		outer, inner = width, height
+	}
+
 	var rawValues [][]any
 
-	if len(resp.ValueRanges) > 0 &&
-		resp.ValueRanges[0].ValueRange != nil &&
-		resp.ValueRanges[0].ValueRange.Values != nil {
-		rawValues = resp.ValueRanges[0].ValueRange.Values
+	if vr != nil && vr.Values != nil {
+		rawValues = vr.Values
 	} else {
 		rawValues = [][]any{}
 	}
 
-	if height < 1 && width < 1 {
-		return rawValues, nil
+	return shapeValues(rawValues, outer, inner), nil
+}
+
+// shapeValues pads rawValues to outer x inner, filling missing cells with "".
+// A dimension less than 1 keeps the size returned by the API.
+func shapeValues(rawValues [][]any, outer int, inner int) [][]any {
+	if outer < 1 && inner < 1 {
+		return rawValues
 	}
 
-	targetRows := height
+	targetRows := outer
 
 	if targetRows < 1 {
 		targetRows = len(rawValues)
@@ -62,7 +81,7 @@ func (sc *SheetClient) getValues(ctx context.Context, row int, col int, height i
 	result := make([][]any, targetRows)
 
 	for r := 0; r < targetRows; r++ {
-		targetCols := width
+		targetCols := inner
 
 		if targetCols < 1 {
 			if r < len(rawValues) {
@@ -85,7 +104,98 @@ func (sc *SheetClient) getValues(ctx context.Context, row int, col int, height i
 		}
 	}
 
-	return result, nil
+	return result
+}
+
+// getValueRange fetches the raw ValueRange for the given range. It returns nil if the response has none.
+func (sc *SheetClient) getValueRange(ctx context.Context, row int, col int, height int, width int, major string) (*sheets.ValueRange, error) {
+	rng := &sheets.GridRange{
+		SheetId:          sc.sheetID,
+		StartRowIndex:    int64(row),
+		StartColumnIndex: int64(col),
+	}
+
+	if height > 0 {
+		rng.EndRowIndex = int64(row + height)
+	}
+
+	if width > 0 {
+		rng.EndColumnIndex = int64(col + width)
+	}
+
+	req := &sheets.BatchGetValuesByDataFilterRequest{
+		DataFilters: []*sheets.DataFilter{
+			{GridRange: rng},
+		},
+		MajorDimension:       major,
+		ValueRenderOption:    string(sc.valueRender),
+		DateTimeRenderOption: string(sc.dateTimeRender),
+	}
+
+	var resp *sheets.BatchGetValuesByDataFilterResponse
+
+	err := sc.c.traceRead(ctx, "GetValues", func() error {
+		var err error
+
+		resp, err = sc.c.service.Spreadsheets.Values.BatchGetByDataFilter(sc.c.spreadID, req).Context(ctx).Do()
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.ValueRanges) == 0 {
+		return nil, nil
+	}
+
+	return resp.ValueRanges[0].ValueRange, nil
+}
+
+// GetRangeRaw returns the unshaped ValueRange for rect, including metadata such as
+// the A1 range and major dimension. Use GetRangeValues for the shaped matrix.
+func (sc *SheetClient) GetRangeRaw(ctx context.Context, rect *Rect) (*sheets.ValueRange, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	if rect == nil {
+		return nil, errors.New("GetRangeRaw: rect should not be nil")
+	}
+
+	err := sc.checkRectInvalid(rect.Row, rect.Col, rect.Height, rect.Width, "GetRangeRaw")
+	if err != nil {
+		return nil, err
+	}
+
+	vr, err := sc.getValueRange(ctx, rect.Row, rect.Col, rect.Height, rect.Width, "ROWS")
+	if err != nil {
+		return nil, fmt.Errorf("GetRangeRaw: failed to read values: %w", err)
+	}
+
+	if vr == nil {
+		vr = &sheets.ValueRange{}
+	}
+
+	return vr, nil
+}
+
+// GetColumnMajor retrieves values in rect indexed by column, then by row.
+func (sc *SheetClient) GetColumnMajor(ctx context.Context, rect *Rect) ([][]any, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	if rect == nil {
+		return nil, errors.New("GetColumnMajor: rect should not be nil")
+	}
+
+	err := sc.checkRectInvalid(rect.Row, rect.Col, rect.Height, rect.Width, "GetColumnMajor")
+	if err != nil {
+		return nil, err
+	}
+
+	return sc.getValuesByDimension(ctx, rect.Row, rect.Col, rect.Height, rect.Width, "COLUMNS")
 }
 
 // GetRangeValues retrieves values using SheetID via DataFilter (ID直指定版)
@@ -102,6 +212,61 @@ func (sc *SheetClient) GetRangeValues(ctx context.Context, row int, col int, hei
 	return sc.getValues(ctx, row, col, height, width)
 }
 
+// GetRangeValuesTrimmed is like GetRangeValues but drops trailing empty rows and columns
+// of open-ended dimensions (rangeUnset), returning the minimal rectangle that contains all
+// non-empty cells. Bounded dimensions keep the requested size, padded with "".
+// Use it for open-ended reads where the real data size is unknown.
+func (sc *SheetClient) GetRangeValuesTrimmed(ctx context.Context, row int, col int, height int, width int) ([][]any, error) {
+	values, err := sc.GetRangeValues(ctx, row, col, height, width)
+	if err != nil {
+		return nil, err
+	}
+
+	return trimValues(values, height, width), nil
+}
+
+// trimValues pads values to a height x width rectangle. A dimension given as rangeUnset
+// is trimmed to the last non-empty row or column instead.
+func trimValues(values [][]any, height int, width int) [][]any {
+	if height == rangeUnset || width == rangeUnset {
+		usedHeight := 0
+		usedWidth := 0
+
+		for r, rowVals := range values {
+			for c, v := range rowVals {
+				if !isEmptyCell(v) {
+					usedHeight = r + 1
+					usedWidth = max(usedWidth, c+1)
+				}
+			}
+		}
+
+		if height == rangeUnset {
+			height = usedHeight
+		}
+
+		if width == rangeUnset {
+			width = usedWidth
+		}
+	}
+
+	result := make([][]any, height)
+
+	for r := range height {
+		result[r] = make([]any, width)
+
+		for c := range width {
+			result[r][c] = ""
+
+			if r < len(values) && c < len(values[r]) && values[r][c] != nil {
+				result[r][c] = values[r][c]
+			}
+		}
+	}
+
+	return result
+}
+
 // GetColValues retrieves values from a specific column.
 func (sc *SheetClient) GetColValues(ctx context.Context, col, width, skipRows int) ([][]any, error) {
 	if sc.err != nil {
@@ -166,10 +331,7 @@ func (sc *SheetClient) checkRectInvalid(row int, col int, height int, width int,
 
 // GetGridSize returns the current grid dimensions (rows and columns) of this sheet.
 func (s *SheetClient) GetGridSize(ctx context.Context) (rowCount, colCount int, err error) {
-	resp, err := s.c.service.Spreadsheets.Get(s.c.spreadID).
-		Fields("sheets(properties(sheetId,gridProperties))").
-		Context(ctx).
-		Do()
+	resp, err := s.c.getSpreadsheet(ctx, "GetGridSize", "sheets(properties(sheetId,gridProperties))")
 	if err != nil {
 		return 0, 0, fmt.Errorf("GetGridSize: failed to fetch spreadsheet info: %w", err)
 	}
@@ -192,3 +354,712 @@ func (s *SheetClient) GetGridSize(ctx context.Context) (rowCount, colCount int,
 
 	return 0, 0, fmt.Errorf("GetGridSize: sheet %d not found", s.sheetID)
 }
+
+// headerScanRows is the default number of rows inspected by FreezeDetectedHeader.
+const headerScanRows = 10
+
+// FreezeDetectedHeader reads the top of the sheet, detects the header rows and
+// returns a builder pre-loaded with a FreezeRows request for them.
+func (sc *SheetClient) FreezeDetectedHeader(ctx context.Context) (*Builder, error) {
+	return sc.FreezeDetectedHeaderN(ctx, headerScanRows)
+}
+
+// FreezeDetectedHeaderN is like FreezeDetectedHeader but inspects at most scanRows rows.
+//
+// Leading non-empty rows that contain no numeric cells are treated as header rows.
+// If no data row is found within scanRows, only the first row is assumed to be a header.
+func (sc *SheetClient) FreezeDetectedHeaderN(ctx context.Context, scanRows int) (*Builder, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	if scanRows < 1 {
+		return nil, fmt.Errorf("FreezeDetectedHeader: invalid scanRows: %d", scanRows)
+	}
+
+	values, err := sc.getValues(ctx, 0, 0, scanRows, rangeUnset)
+	if err != nil {
+		return nil, fmt.Errorf("FreezeDetectedHeader: failed to read values: %w", err)
+	}
+
+	rows := detectHeaderRows(values)
+	if rows == 0 {
+		return nil, fmt.Errorf("FreezeDetectedHeader: no header rows detected in sheet %d", sc.sheetID)
+	}
+
+	b := sc.c.Builder()
+
+	b.Sheet(sc.sheetID).FreezeRows(rows)
+
+	return b, nil
+}
+
+// detectHeaderRows counts the leading rows that look like headers.
+func detectHeaderRows(values [][]any) int {
+	n := 0
+
+	for _, row := range values {
+		if isEmptyRow(row) || hasNumericCell(row) {
+			break
+		}
+
+		n++
+	}
+
+	// データ行が見つからなかった場合は判別できないので先頭行のみとする
+	if n == len(values) && n > 1 {
+		n = 1
+	}
+
+	return n
+}
+
+// isEmptyRow reports whether every cell in row is empty.
+func isEmptyRow(row []any) bool {
+	for _, v := range row {
+		if !isEmptyCell(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasNumericCell reports whether row contains a number or a numeric-looking string.
+func hasNumericCell(row []any) bool {
+	for _, v := range row {
+		switch val := v.(type) {
+		case float64, int:
+			return true
+		case string:
+			s := strings.TrimSpace(val)
+			s = strings.TrimSuffix(s, "%")
+			s = strings.ReplaceAll(s, ",", "")
+
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isEmptyCell reports whether v represents an empty cell value.
+func isEmptyCell(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	s, ok := v.(string)
+
+	return ok && s == ""
+}
+
+// CopyFormatFrom returns a builder that copies the formatting of the src sheet onto this sheet.
+// The copied area is capped to the smaller of both grids so it never exceeds this sheet's size.
+func (sc *SheetClient) CopyFormatFrom(ctx context.Context, srcSheetID int64) (*Builder, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	if srcSheetID < 0 {
+		return nil, fmt.Errorf("CopyFormatFrom: invalid src sheet id: %d", srcSheetID)
+	}
+
+	grids, err := sc.c.getGridProperties(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("CopyFormatFrom: %w", err)
+	}
+
+	src, ok := grids[srcSheetID]
+	if !ok {
+		return nil, fmt.Errorf("CopyFormatFrom: sheet %d not found", srcSheetID)
+	}
+
+	dst, ok := grids[sc.sheetID]
+	if !ok {
+		return nil, fmt.Errorf("CopyFormatFrom: sheet %d not found", sc.sheetID)
+	}
+
+	rows := int(min(src.RowCount, dst.RowCount))
+	cols := int(min(src.ColumnCount, dst.ColumnCount))
+
+	b := sc.c.Builder()
+
+	b.copySheetFormat(srcSheetID, sc.sheetID, rows, cols)
+
+	return b, nil
+}
+
+// Exists reports whether this sheet is present in the spreadsheet.
+// A missing sheet returns false with a nil error; only API failures return an error.
+func (sc *SheetClient) Exists(ctx context.Context) (bool, error) {
+	if sc.err != nil {
+		return false, sc.err
+	}
+
+	resp, err := sc.c.getSpreadsheet(ctx, "Exists", "sheets(properties(sheetId))")
+	if err != nil {
+		return false, fmt.Errorf("Exists: failed to fetch spreadsheet info: %w", err)
+	}
+
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties != nil && sheet.Properties.SheetId == sc.sheetID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CountNonEmpty returns the number of non-empty cells in rect.
+// Values are read unformatted regardless of the client's render option.
+func (sc *SheetClient) CountNonEmpty(ctx context.Context, rect *Rect) (int, error) {
+	if sc.err != nil {
+		return 0, sc.err
+	}
+
+	if rect == nil {
+		return 0, errors.New("CountNonEmpty: rect should not be nil")
+	}
+
+	err := sc.checkRectInvalid(rect.Row, rect.Col, rect.Height, rect.Width, "CountNonEmpty")
+	if err != nil {
+		return 0, err
+	}
+
+	unformatted := *sc
+	unformatted.valueRender = ValueRenderOptionUnformatted
+
+	vr, err := unformatted.getValueRange(ctx, rect.Row, rect.Col, rect.Height, rect.Width, "ROWS")
+	if err != nil {
+		return 0, fmt.Errorf("CountNonEmpty: failed to read values: %w", err)
+	}
+
+	if vr == nil {
+		return 0, nil
+	}
+
+	count := 0
+
+	for _, row := range vr.Values {
+		for _, v := range row {
+			if !isEmptyCell(v) {
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// LastDataRow returns the 0-based index of the last non-empty cell in col, or -1 if the column is empty.
+func (sc *SheetClient) LastDataRow(ctx context.Context, col int) (int, error) {
+	if sc.err != nil {
+		return 0, sc.err
+	}
+
+	if col < 0 {
+		return 0, fmt.Errorf("LastDataRow: invalid col: %d", col)
+	}
+
+	vr, err := sc.getValueRange(ctx, 0, col, rangeUnset, 1, "ROWS")
+	if err != nil {
+		return 0, fmt.Errorf("LastDataRow: failed to read values: %w", err)
+	}
+
+	if vr == nil {
+		return -1, nil
+	}
+
+	for r := len(vr.Values) - 1; r >= 0; r-- {
+		if len(vr.Values[r]) > 0 && !isEmptyCell(vr.Values[r][0]) {
+			return r, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// GetLargeRange reads rect in chunks of chunkRows rows and concatenates the results,
+// avoiding a single huge response. If rect.Height is rangeUnset, rows are read down to the
+// end of the sheet's grid (fetched with GetGridSize), and trailing empty rows are dropped.
+// Cancelation is checked between chunks.
+func (sc *SheetClient) GetLargeRange(ctx context.Context, rect *Rect, chunkRows int) ([][]any, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	if rect == nil {
+		return nil, errors.New("GetLargeRange: rect should not be nil")
+	}
+
+	err := sc.checkRectInvalid(rect.Row, rect.Col, rect.Height, rect.Width, "GetLargeRange")
+	if err != nil {
+		return nil, err
+	}
+
+	if chunkRows < 1 {
+		return nil, fmt.Errorf("GetLargeRange: invalid chunkRows: %d", chunkRows)
+	}
+
+	bounded := rect.Height != rangeUnset
+
+	end := rect.Row + rect.Height
+
+	if !bounded {
+		// 空行が続いても途中で止めず、グリッドの最終行まで読む
+		rowCount, _, err := sc.GetGridSize(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetLargeRange: %w", err)
+		}
+
+		end = rowCount
+	}
+
+	var rawValues [][]any
+
+	for start := rect.Row; start < end; start += chunkRows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		height := min(chunkRows, end-start)
+
+		vr, err := sc.getValueRange(ctx, start, rect.Col, height, rect.Width, "ROWS")
+		if err != nil {
+			return nil, fmt.Errorf("GetLargeRange: failed to read rows from %d: %w", start, err)
+		}
+
+		var chunk [][]any
+
+		if vr != nil {
+			chunk = vr.Values
+		}
+
+		// 末尾の空行は API が返さないので、次のチャンクと行がずれないよう詰める
+		for len(chunk) < height {
+			chunk = append(chunk, []any{})
+		}
+
+		rawValues = append(rawValues, chunk...)
+	}
+
+	if !bounded {
+		// 詰め物の空行を取り除く
+		for len(rawValues) > 0 && len(rawValues[len(rawValues)-1]) == 0 {
+			rawValues = rawValues[:len(rawValues)-1]
+		}
+	}
+
+	if rawValues == nil {
+		rawValues = [][]any{}
+	}
+
+	return shapeValues(rawValues, rect.Height, rect.Width), nil
+}
+
+// sheetTitle fetches the current title of this sheet.
+func (sc *SheetClient) sheetTitle(ctx context.Context, label string) (string, error) {
+	resp, err := sc.c.getSpreadsheet(ctx, label, "sheets(properties(sheetId,title))")
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to fetch spreadsheet info: %w", label, err)
+	}
+
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties != nil && sheet.Properties.SheetId == sc.sheetID {
+			return sheet.Properties.Title, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s: sheet %d not found", label, sc.sheetID)
+}
+
+// AppendValues appends values as new rows below the table found in tableRange,
+// using the spreadsheets.values.append endpoint. The API detects where the table ends.
+// valueInputOption is "USER_ENTERED" (default if empty) or "RAW".
+func (sc *SheetClient) AppendValues(ctx context.Context, tableRange *Rect, values [][]any, valueInputOption string) error {
+	if sc.err != nil {
+		return sc.err
+	}
+
+	if tableRange == nil {
+		return errors.New("AppendValues: tableRange should not be nil")
+	}
+
+	err := sc.checkRectInvalid(tableRange.Row, tableRange.Col, tableRange.Height, tableRange.Width, "AppendValues")
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return errors.New("AppendValues: values should not be nil or empty")
+	}
+
+	if valueInputOption == "" {
+		valueInputOption = "USER_ENTERED"
+	}
+
+	a1, err := rectToA1(tableRange)
+	if err != nil {
+		return fmt.Errorf("AppendValues: invalid tableRange: %w", err)
+	}
+
+	title, err := sc.sheetTitle(ctx, "AppendValues")
+	if err != nil {
+		return err
+	}
+
+	_, err = sc.c.service.Spreadsheets.Values.Append(sc.c.spreadID, quoteSheetTitle(title)+"!"+a1, &sheets.ValueRange{
+		Values: values,
+	}).
+		ValueInputOption(valueInputOption).
+		InsertDataOption("INSERT_ROWS").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return fmt.Errorf("AppendValues: failed to append values: %w", err)
+	}
+
+	return nil
+}
+
+// ClearAllConditionalFormats returns a builder that deletes every conditional format rule on this sheet.
+// Rules are deleted from the highest index down so that the remaining indices stay valid.
+func (sc *SheetClient) ClearAllConditionalFormats(ctx context.Context) (*Builder, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	resp, err := sc.c.getSpreadsheet(ctx, "ClearAllConditionalFormats", "sheets(properties(sheetId),conditionalFormats(ranges(sheetId)))")
+	if err != nil {
+		return nil, fmt.Errorf("ClearAllConditionalFormats: failed to fetch spreadsheet info: %w", err)
+	}
+
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties == nil || sheet.Properties.SheetId != sc.sheetID {
+			continue
+		}
+
+		b := sc.c.Builder()
+
+		for i := len(sheet.ConditionalFormats) - 1; i >= 0; i-- {
+			b.AppendRequest(&sheets.Request{
+				DeleteConditionalFormatRule: &sheets.DeleteConditionalFormatRuleRequest{
+					SheetId: sc.sheetID,
+					Index:   int64(i),
+				},
+			})
+		}
+
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("ClearAllConditionalFormats: sheet %d not found", sc.sheetID)
+}
+
+// CellColors holds the effective colors of a cell. Fields are nil when the cell has no such color.
+type CellColors struct {
+	Background *sheets.Color
+	Foreground *sheets.Color // text color
+}
+
+// GetRangeColors returns the effective background and text colors of each cell in rect.
+// The result is indexed by row, then column, relative to rect.
+// Cells without an effective format are nil.
+func (sc *SheetClient) GetRangeColors(ctx context.Context, rect *Rect) ([][]*CellColors, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	if rect == nil {
+		return nil, errors.New("GetRangeColors: rect should not be nil")
+	}
+
+	err := sc.checkRectInvalid(rect.Row, rect.Col, rect.Height, rect.Width, "GetRangeColors")
+	if err != nil {
+		return nil, err
+	}
+
+	rng := &sheets.GridRange{
+		SheetId:          sc.sheetID,
+		StartRowIndex:    int64(rect.Row),
+		StartColumnIndex: int64(rect.Col),
+	}
+
+	if rect.Height > 0 {
+		rng.EndRowIndex = int64(rect.Row + rect.Height)
+	}
+
+	if rect.Width > 0 {
+		rng.EndColumnIndex = int64(rect.Col + rect.Width)
+	}
+
+	req := &sheets.GetSpreadsheetByDataFilterRequest{
+		DataFilters:     []*sheets.DataFilter{{GridRange: rng}},
+		IncludeGridData: true,
+	}
+
+	var resp *sheets.Spreadsheet
+
+	err = sc.c.traceRead(ctx, "GetRangeColors", func() error {
+		var err error
+
+		resp, err = sc.c.service.Spreadsheets.GetByDataFilter(sc.c.spreadID, req).
+			Fields("sheets(data(rowData(values(effectiveFormat(backgroundColor,textFormat(foregroundColor))))))").
+			Context(ctx).
+			Do()
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetRangeColors: failed to read formats: %w", err)
+	}
+
+	var rows []*sheets.RowData
+
+	if len(resp.Sheets) > 0 && len(resp.Sheets[0].Data) > 0 {
+		rows = resp.Sheets[0].Data[0].RowData
+	}
+
+	height := rect.Height
+	if height == rangeUnset {
+		height = len(rows)
+	}
+
+	width := rect.Width
+	if width == rangeUnset {
+		for _, r := range rows {
+			width = max(width, len(r.Values))
+		}
+	}
+
+	result := make([][]*CellColors, height)
+
+	for i := range result {
+		result[i] = make([]*CellColors, width)
+
+		if i >= len(rows) {
+			continue
+		}
+
+		for j, cell := range rows[i].Values {
+			if j >= width || cell == nil || cell.EffectiveFormat == nil {
+				continue
+			}
+
+			colors := &CellColors{Background: cell.EffectiveFormat.BackgroundColor}
+
+			if cell.EffectiveFormat.TextFormat != nil {
+				colors.Foreground = cell.EffectiveFormat.TextFormat.ForegroundColor
+			}
+
+			result[i][j] = colors
+		}
+	}
+
+	return result, nil
+}
+
+// GetValuesAs reads rect from sc and converts each cell with convert.
+// row and col passed to convert are absolute sheet indexes. Empty cells are passed as "".
+// Conversion errors are collected with their cell coordinates and returned together.
+func GetValuesAs[T any](ctx context.Context, sc *SheetClient, rect *Rect, convert func(row, col int, raw any) (T, error)) ([][]T, error) {
+	if rect == nil {
+		return nil, errors.New("GetValuesAs: rect should not be nil")
+	}
+
+	if convert == nil {
+		return nil, errors.New("GetValuesAs: convert should not be nil")
+	}
+
+	values, err := sc.GetRangeValues(ctx, rect.Row, rect.Col, rect.Height, rect.Width)
+	if err != nil {
+		return nil, fmt.Errorf("GetValuesAs: %w", err)
+	}
+
+	var errs []error
+
+	result := make([][]T, len(values))
+
+	for i, rowVals := range values {
+		result[i] = make([]T, len(rowVals))
+
+		for j, raw := range rowVals {
+			row, col := rect.Row+i, rect.Col+j
+
+			v, err := convert(row, col, raw)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("GetValuesAs: %s: %w", MustIndexToA1At(row, col), err))
+
+				continue
+			}
+
+			result[i][j] = v
+		}
+	}
+
+	if len(errs) > 0 {
+		return result, errors.Join(errs...)
+	}
+
+	return result, nil
+}
+
+// GetSheetRevision returns the spreadsheet file's Drive version, which increases on every change.
+// The Sheets API has no revision signal, so the client needs a Drive service set with
+// Client.WithDriveService. The version covers the whole spreadsheet, not only this sheet.
+func (sc *SheetClient) GetSheetRevision(ctx context.Context) (string, error) {
+	if sc.err != nil {
+		return "", sc.err
+	}
+
+	drv := sc.c.drive
+	if drv == nil {
+		return "", errors.New("GetSheetRevision: no drive service; set one with Client.WithDriveService")
+	}
+
+	var file *drive.File
+
+	err := sc.c.traceRead(ctx, "GetSheetRevision", func() error {
+		var err error
+
+		file, err = drv.Files.Get(sc.c.spreadID).
+			Fields("version").
+			SupportsAllDrives(true).
+			Context(ctx).
+			Do()
+
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("GetSheetRevision: failed to fetch file metadata: %w", err)
+	}
+
+	return strconv.FormatInt(file.Version, 10), nil
+}
+
+// ChangedSince reports whether the spreadsheet has changed since revision prev
+// (as returned by GetSheetRevision) and returns the current revision.
+// An empty prev is always reported as changed.
+func (sc *SheetClient) ChangedSince(ctx context.Context, prev string) (bool, string, error) {
+	cur, err := sc.GetSheetRevision(ctx)
+	if err != nil {
+		return false, "", err
+	}
+
+	return cur != prev, cur, nil
+}
+
+// BoldFirstWord reads rect and returns a builder that makes the first word of each text cell bold.
+// Words are separated by whitespace. Empty cells, numbers, booleans and formulas are left unchanged.
+func (sc *SheetClient) BoldFirstWord(ctx context.Context, rect *Rect) (*Builder, error) {
+	if sc.err != nil {
+		return nil, sc.err
+	}
+
+	if rect == nil {
+		return nil, errors.New("BoldFirstWord: rect should not be nil")
+	}
+
+	err := sc.checkRectInvalid(rect.Row, rect.Col, rect.Height, rect.Width, "BoldFirstWord")
+	if err != nil {
+		return nil, err
+	}
+
+	formulas := *sc
+	formulas.valueRender = ValueRenderOptionFormula
+
+	vr, err := formulas.getValueRange(ctx, rect.Row, rect.Col, rect.Height, rect.Width, "ROWS")
+	if err != nil {
+		return nil, fmt.Errorf("BoldFirstWord: failed to read values: %w", err)
+	}
+
+	b := sc.c.Builder()
+
+	if vr == nil {
+		return b, nil
+	}
+
+	for i, row := range vr.Values {
+		for j, v := range row {
+			s, ok := v.(string)
+			if !ok || s == "" || strings.HasPrefix(s, "=") {
+				continue
+			}
+
+			runs := firstWordRuns(s)
+			if runs == nil {
+				continue
+			}
+
+			b.AppendRequest(&sheets.Request{
+				UpdateCells: &sheets.UpdateCellsRequest{
+					Start: &sheets.GridCoordinate{
+						SheetId:     sc.sheetID,
+						RowIndex:    int64(rect.Row + i),
+						ColumnIndex: int64(rect.Col + j),
+					},
+					Rows: []*sheets.RowData{
+						{Values: []*sheets.CellData{{TextFormatRuns: runs}}},
+					},
+					Fields: "textFormatRuns",
+				},
+			})
+		}
+	}
+
+	return b, nil
+}
+
+// firstWordRuns returns text format runs that make the first word of s bold.
+// Indexes are in UTF-16 code units as the API expects. It returns nil if s has no word.
+func firstWordRuns(s string) []*sheets.TextFormatRun {
+	start := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsSpace(r) })
+	if start < 0 {
+		return nil
+	}
+
+	end := len(s)
+
+	if n := strings.IndexFunc(s[start:], unicode.IsSpace); n >= 0 {
+		end = start + n
+	}
+
+	startIdx := utf16Len(s[:start])
+	endIdx := utf16Len(s[:end])
+
+	runs := make([]*sheets.TextFormatRun, 0, 3)
+
+	if startIdx > 0 {
+		runs = append(runs, &sheets.TextFormatRun{
+			Format: &sheets.TextFormat{ForceSendFields: []string{"Bold"}},
+		})
+	}
+
+	runs = append(runs, &sheets.TextFormatRun{
+		StartIndex: int64(startIdx),
+		Format:     &sheets.TextFormat{Bold: true},
+	})
+
+	if end < len(s) {
+		runs = append(runs, &sheets.TextFormatRun{
+			StartIndex: int64(endIdx),
+			Format:     &sheets.TextFormat{ForceSendFields: []string{"Bold"}},
+		})
+	}
+
+	return runs
+}
+
+// utf16Len returns the length of s in UTF-16 code units.
+func utf16Len(s string) int {
+	n := 0
+
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+
+	return n
+}
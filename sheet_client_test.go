@@ -0,0 +1,66 @@
+package haresheet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrimValues(t *testing.T) {
+	values := [][]any{
+		{"a", "", nil},
+		{"", "b"},
+		{""},
+	}
+
+	tests := []struct {
+		name          string
+		height, width int
+		want          [][]any
+	}{
+		{
+			name:   "both open",
+			height: rangeUnset,
+			width:  rangeUnset,
+			want:   [][]any{{"a", ""}, {"", "b"}},
+		},
+		{
+			name:   "bounded height",
+			height: 4,
+			width:  rangeUnset,
+			want:   [][]any{{"a", ""}, {"", "b"}, {"", ""}, {"", ""}},
+		},
+		{
+			name:   "bounded width",
+			height: rangeUnset,
+			width:  3,
+			want:   [][]any{{"a", "", ""}, {"", "b", ""}},
+		},
+		{
+			name:   "both bounded",
+			height: 3,
+			width:  1,
+			want:   [][]any{{"a"}, {""}, {""}},
+		},
+	}
+
+	for _, tt := range tests {
+		got := trimValues(values, tt.height, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: trimValues() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTrimValuesEmpty(t *testing.T) {
+	got := trimValues(nil, rangeUnset, rangeUnset)
+	if len(got) != 0 {
+		t.Errorf("trimValues(nil) = %v, want empty", got)
+	}
+
+	got = trimValues(nil, 2, 2)
+	want := [][]any{{"", ""}, {"", ""}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("trimValues(nil, 2, 2) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package haresheet
+
+import "reflect"
+
+// StrictRow is a Row wrapper that panics when a nil value is stored.
+//
+// It catches accidental nils (which become blank cells) while building rows.
+// Slots reserved by Grow, or skipped over by Put/Puts, still hold zero values.
+type StrictRow[T any] struct {
+	r *Row[T]
+}
+
+// NewStrictRow creates a StrictRow with optional initial capacity.
+func NewStrictRow[T any](capacity int) *StrictRow[T] {
+	return &StrictRow[T]{
+		r: NewRow[T](capacity),
+	}
+}
+
+// Set sets a value at index i (panics on out-of-range like a slice assignment).
+func (sr *StrictRow[T]) Set(i int, v T) {
+	mustNotNil("strictrow.Set", v)
+
+	sr.r.Set(i, v)
+}
+
+// Sets writes values starting at start, growing the row if needed, and returns r for chaining.
+func (sr *StrictRow[T]) Sets(start int, values ...T) *StrictRow[T] {
+	for _, v := range values {
+		mustNotNil("strictrow.Sets", v)
+	}
+
+	sr.r.Sets(start, values...)
+
+	return sr
+}
+
+// Put sets v at index i, growing the row to i+1 if needed.
+func (sr *StrictRow[T]) Put(i int, v T) {
+	mustNotNil("strictrow.Put", v)
+
+	sr.r.Put(i, v)
+}
+
+// Puts writes values starting at start, growing as needed.
+func (sr *StrictRow[T]) Puts(start int, values ...T) *StrictRow[T] {
+	for _, v := range values {
+		mustNotNil("strictrow.Puts", v)
+	}
+
+	sr.r.Puts(start, values...)
+
+	return sr
+}
+
+// Append appends a value to the end of the row.
+func (sr *StrictRow[T]) Append(v T) *StrictRow[T] {
+	mustNotNil("strictrow.Append", v)
+
+	sr.r.Append(v)
+
+	return sr
+}
+
+// Row returns the underlying Row.
+func (sr *StrictRow[T]) Row() *Row[T] {
+	return sr.r
+}
+
+// --------------------------------------------------------------------------------
+// safe passthroughs
+// --------------------------------------------------------------------------------
+
+// At returns the value at index i (panics on out-of-range like a slice access).
+func (sr *StrictRow[T]) At(i int) T { return sr.r.At(i) }
+
+// Get returns the value at index i and whether it exists.
+func (sr *StrictRow[T]) Get(i int) (T, bool) { return sr.r.Get(i) }
+
+// Len returns the current length of the underlying row.
+func (sr *StrictRow[T]) Len() int { return sr.r.Len() }
+
+// Cap returns the current capacity of the underlying row.
+func (sr *StrictRow[T]) Cap() int { return sr.r.Cap() }
+
+// Grow extends the underlying row by n zero-value elements.
+func (sr *StrictRow[T]) Grow(n int) int { return sr.r.Grow(n) }
+
+// Clear resets the underlying row length to zero, keeping capacity for reuse.
+func (sr *StrictRow[T]) Clear() { sr.r.Clear() }
+
+// Slice returns the underlying slice of the row.
+func (sr *StrictRow[T]) Slice() []T { return sr.r.Slice() }
+
+// Values returns a copy of the current contents of the row.
+func (sr *StrictRow[T]) Values() []T { return sr.r.Values() }
+
+// mustNotNil panics if v is nil (a nil interface, pointer, map, slice, func or chan).
+func mustNotNil[T any](label string, v T) {
+	if isNilValue(v) {
+		panic(label + ": nil value")
+	}
+}
+
+// isNilValue reports whether v is nil.
+func isNilValue(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
@@ -1,5 +1,7 @@
 package haresheet
 
+import "google.golang.org/api/sheets/v4"
+
 // PasteType defines the type of content to paste.
 type PasteType string
 
@@ -27,6 +29,103 @@ const (
 	ShiftDimensionTypeColumns ShiftDimensionType = "COLUMNS" // 列方向（削除したら右から左に詰める）
 )
 
+// NumberFormatType defines how numbers in a cell are displayed.
+type NumberFormatType string
+
+const (
+	NumberFormatTypeText       NumberFormatType = "TEXT"
+	NumberFormatTypeNumber     NumberFormatType = "NUMBER"
+	NumberFormatTypePercent    NumberFormatType = "PERCENT"
+	NumberFormatTypeCurrency   NumberFormatType = "CURRENCY"
+	NumberFormatTypeDate       NumberFormatType = "DATE"
+	NumberFormatTypeTime       NumberFormatType = "TIME"
+	NumberFormatTypeDateTime   NumberFormatType = "DATE_TIME"
+	NumberFormatTypeScientific NumberFormatType = "SCIENTIFIC"
+)
+
+// HAlign defines the horizontal alignment of cell contents.
+type HAlign string
+
+const (
+	HAlignLeft   HAlign = "LEFT"
+	HAlignCenter HAlign = "CENTER"
+	HAlignRight  HAlign = "RIGHT"
+)
+
+// VAlign defines the vertical alignment of cell contents.
+type VAlign string
+
+const (
+	VAlignTop    VAlign = "TOP"
+	VAlignMiddle VAlign = "MIDDLE"
+	VAlignBottom VAlign = "BOTTOM"
+)
+
+// Border describes one border line for SetBorders.
+// Style is an API style such as "SOLID", "SOLID_MEDIUM", "SOLID_THICK", "DASHED", "DOTTED", "DOUBLE" or "NONE".
+// An empty Style means "SOLID".
+type Border struct {
+	Style string
+	Color *sheets.Color
+}
+
+// Borders describes the borders of a range. Nil sides are left unchanged.
+// Diagonal borders are not supported: UpdateBordersRequest in the Sheets API has no diagonal fields.
+type Borders struct {
+	Top             *Border
+	Bottom          *Border
+	Left            *Border
+	Right           *Border
+	InnerHorizontal *Border
+	InnerVertical   *Border
+}
+
+// SortSpec describes one sort key for SortRange.
+// ColumnOffset is relative to the sorted rect's first column.
+type SortSpec struct {
+	ColumnOffset int
+	Ascending    bool
+}
+
+// TextStyle describes text formatting for SetTextStyle.
+// Nil pointers, an empty FontFamily and a zero FontSize leave the existing formatting untouched.
+type TextStyle struct {
+	Bold          *bool
+	Italic        *bool
+	Underline     *bool
+	Strikethrough *bool
+	FontFamily    string
+	FontSize      int
+}
+
+// InputMode defines how string values written by the builder are interpreted.
+type InputMode string
+
+const (
+	// InputModeUserEntered treats strings starting with "=" as formulas (default).
+	InputModeUserEntered InputMode = "USER_ENTERED"
+	// InputModeRaw stores every string as literal text, even if it starts with "=".
+	InputModeRaw InputMode = "RAW"
+)
+
+// ValueRenderOption defines how values should be rendered when reading.
+type ValueRenderOption string
+
+const (
+	ValueRenderOptionFormatted   ValueRenderOption = "FORMATTED_VALUE"   // 表示されている文字列
+	ValueRenderOptionUnformatted ValueRenderOption = "UNFORMATTED_VALUE" // 書式なしの値（数値は数値のまま）
+	ValueRenderOptionFormula     ValueRenderOption = "FORMULA"           // 数式そのもの
+)
+
+// DateTimeRenderOption defines how dates and times should be rendered when reading.
+// It is ignored when ValueRenderOption is ValueRenderOptionFormatted.
+type DateTimeRenderOption string
+
+const (
+	DateTimeRenderOptionSerialNumber    DateTimeRenderOption = "SERIAL_NUMBER"    // シリアル値
+	DateTimeRenderOptionFormattedString DateTimeRenderOption = "FORMATTED_STRING" // 書式適用後の文字列
+)
+
 type Rect struct {
 	Row    int
 	Col    int
@@ -34,6 +133,69 @@ type Rect struct {
 	Width  int
 }
 
+// RectForMatrix returns the rect covered by writing values at (startRow, startCol).
+// Width is the length of the longest inner slice.
+func RectForMatrix(startRow int, startCol int, values [][]any) *Rect {
+	width := 0
+
+	for _, row := range values {
+		width = max(width, len(row))
+	}
+
+	return &Rect{
+		Row:    startRow,
+		Col:    startCol,
+		Height: len(values),
+		Width:  width,
+	}
+}
+
+// Each calls fn for every cell in the rect, row by row.
+// It panics if the rect is unbounded (Height or Width is unset).
+func (r *Rect) Each(fn func(row, col int)) {
+	r.mustBounded("rect.Each")
+
+	for row := r.Row; row < r.Row+r.Height; row++ {
+		for col := r.Col; col < r.Col+r.Width; col++ {
+			fn(row, col)
+		}
+	}
+}
+
+// EachRow calls fn for every row index in the rect.
+// It panics if the rect's Height is unset.
+func (r *Rect) EachRow(fn func(row int)) {
+	if r.Height < 0 {
+		panic("rect.EachRow: unbounded height")
+	}
+
+	for row := r.Row; row < r.Row+r.Height; row++ {
+		fn(row)
+	}
+}
+
+// EachCol calls fn for every column index in the rect.
+// It panics if the rect's Width is unset.
+func (r *Rect) EachCol(fn func(col int)) {
+	if r.Width < 0 {
+		panic("rect.EachCol: unbounded width")
+	}
+
+	for col := r.Col; col < r.Col+r.Width; col++ {
+		fn(col)
+	}
+}
+
+func (r *Rect) mustBounded(label string) {
+	if r.Height < 0 {
+		panic(label + ": unbounded height")
+	}
+
+	if r.Width < 0 {
+		panic(label + ": unbounded width")
+	}
+}
+
 // SheetRow is a Row specialized for spreadsheet cells (values, formulas, etc).
 type SheetRow = Row[any]
 
@@ -49,3 +211,11 @@ type OffsetSheetRow = OffsetRow[any]
 func NewOffsetSheetRow(offset int, capacity int) *OffsetSheetRow {
 	return NewOffsetRow[any](offset, capacity)
 }
+
+// StrictSheetRow is a StrictRow specialized for spreadsheet cells (values, formulas, etc).
+type StrictSheetRow = StrictRow[any]
+
+// NewStrictSheetRow
+func NewStrictSheetRow(capacity int) *StrictSheetRow {
+	return NewStrictRow[any](capacity)
+}
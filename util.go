@@ -4,7 +4,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"image/color"
+	"math"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"google.golang.org/api/sheets/v4"
 )
@@ -31,12 +36,9 @@ func IndexToA1(row int, col int, rowOffset int, colOffset int) (string, error) {
 	}
 
 	// col letters (0-based) + row number (1-based)
-	colLetters := ColIndexToLetters(c)
-
 	// Build result with minimal allocations.
-	// Max column letters for int range is small; allocate enough.
-	b := make([]byte, 0, len(colLetters)+10)
-	b = append(b, colLetters...)
+	var buf [32]byte
+	b := appendColLetters(buf[:0], c)
 	b = strconv.AppendInt(b, int64(r+1), 10)
 
 	return string(b), nil
@@ -73,17 +75,16 @@ func IndexToA1Abs(row, col, rowOffset, colOffset int, abs AbsMode) (string, erro
 		return "", ErrNegativeIndex
 	}
 
-	colLetters := ColIndexToLetters(c)
-
 	// Build result with minimal allocations.
 	// Capacity: [$] + col + [$] + row digits
-	b := make([]byte, 0, 1+len(colLetters)+1+10)
+	var buf [40]byte
+	b := buf[:0]
 
 	if abs&AbsCol != 0 {
 		b = append(b, '$')
 	}
 
-	b = append(b, colLetters...)
+	b = appendColLetters(b, c)
 
 	if abs&AbsRow != 0 {
 		b = append(b, '$')
@@ -115,10 +116,21 @@ func MustIndexToA1AtAbs(row, col int, abs AbsMode) string {
 }
 
 // ColIndexToLetters converts 0-based column index to letters (A..Z, AA..).
+// A negative col returns nil.
 func ColIndexToLetters(col int) []byte {
-	// We build in reverse then reverse once.
+	if col < 0 {
+		return nil
+	}
+
+	return appendColLetters(nil, col)
+}
+
+// appendColLetters appends the column letters for a non-negative col to dst.
+func appendColLetters(dst []byte, col int) []byte {
+	// We build in reverse then copy once.
 	// For typical sheet sizes this is tiny (<= 3-4 chars).
-	var tmp [8]byte
+	// 26^14 exceeds the int64 range, so 16 bytes is enough for any col.
+	var tmp [16]byte
 
 	i := len(tmp)
 
@@ -130,12 +142,17 @@ func ColIndexToLetters(col int) []byte {
 		col = col/26 - 1
 	}
 
-	// Copy to a new slice of exact size.
-	out := make([]byte, len(tmp)-i)
+	return append(dst, tmp[i:]...)
+}
 
-	copy(out, tmp[i:])
+// ColIndexToLettersSafe converts 0-based column index to letters (A..Z, AA..).
+// It returns ErrNegativeIndex if col is negative.
+func ColIndexToLettersSafe(col int) (string, error) {
+	if col < 0 {
+		return "", ErrNegativeIndex
+	}
 
-	return out
+	return string(ColIndexToLetters(col)), nil
 }
 
 // ParseHexColor parses a hex string (e.g. "#FFFFFF" or "FFFFFF") to *sheets.Color.
@@ -172,3 +189,300 @@ func MustParseHexColor(s string) *sheets.Color {
 
 	return c
 }
+
+// GridRangeEqual reports whether a and b cover the same cells of the same sheet.
+// An end index of 0 is treated as unbounded, matching how the API omits it.
+func GridRangeEqual(a, b *sheets.GridRange) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.SheetId == b.SheetId &&
+		a.StartRowIndex == b.StartRowIndex &&
+		a.EndRowIndex == b.EndRowIndex &&
+		a.StartColumnIndex == b.StartColumnIndex &&
+		a.EndColumnIndex == b.EndColumnIndex
+}
+
+// GridRangeContains reports whether inner lies entirely within outer.
+// An end index of 0 is treated as unbounded, so a bounded outer range never
+// contains an unbounded inner range.
+func GridRangeContains(outer, inner *sheets.GridRange) bool {
+	if outer == nil || inner == nil {
+		return false
+	}
+
+	if outer.SheetId != inner.SheetId {
+		return false
+	}
+
+	return spanContains(outer.StartRowIndex, outer.EndRowIndex, inner.StartRowIndex, inner.EndRowIndex) &&
+		spanContains(outer.StartColumnIndex, outer.EndColumnIndex, inner.StartColumnIndex, inner.EndColumnIndex)
+}
+
+// spanContains reports whether [innerStart, innerEnd) lies within [outerStart, outerEnd).
+// An end of 0 means unbounded.
+func spanContains(outerStart, outerEnd, innerStart, innerEnd int64) bool {
+	if innerStart < outerStart {
+		return false
+	}
+
+	if outerEnd == 0 {
+		return true
+	}
+
+	if innerEnd == 0 {
+		return false
+	}
+
+	return innerEnd <= outerEnd
+}
+
+// rectToA1 converts a rect into an A1 range such as "A1:C5".
+// An unset Height yields an open-ended range such as "A1:C".
+func rectToA1(rect *Rect) (string, error) {
+	if rect.Width < 1 {
+		return "", fmt.Errorf("rectToA1: invalid width: %d", rect.Width)
+	}
+
+	start, err := IndexToA1At(rect.Row, rect.Col)
+	if err != nil {
+		return "", err
+	}
+
+	endCol := string(ColIndexToLetters(rect.Col + rect.Width - 1))
+
+	if rect.Height == rangeUnset {
+		return start + ":" + endCol, nil
+	}
+
+	if rect.Height < 1 {
+		return "", fmt.Errorf("rectToA1: invalid height: %d", rect.Height)
+	}
+
+	return start + ":" + endCol + strconv.Itoa(rect.Row+rect.Height), nil
+}
+
+// quoteSheetTitle quotes a sheet title for use in A1 notation (e.g. 'My Sheet'!A1).
+func quoteSheetTitle(title string) string {
+	return "'" + strings.ReplaceAll(title, "'", "''") + "'"
+}
+
+// ColorToRGBA converts a *sheets.Color to a color.RGBA.
+// A nil color returns transparent. An omitted alpha is treated as opaque, as the API does.
+func ColorToRGBA(c *sheets.Color) color.RGBA {
+	if c == nil {
+		return color.RGBA{}
+	}
+
+	alpha := c.Alpha
+
+	if alpha == 0 && !slices.Contains(c.ForceSendFields, "Alpha") {
+		alpha = 1.0
+	}
+
+	nrgba := color.NRGBA{
+		R: unitToByte(c.Red),
+		G: unitToByte(c.Green),
+		B: unitToByte(c.Blue),
+		A: unitToByte(alpha),
+	}
+
+	return color.RGBAModel.Convert(nrgba).(color.RGBA)
+}
+
+// ColorFromColor converts a color.Color to a *sheets.Color.
+// A nil color returns transparent.
+func ColorFromColor(c color.Color) *sheets.Color {
+	if c == nil {
+		return &sheets.Color{ForceSendFields: []string{"Alpha"}}
+	}
+
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+
+	sc := &sheets.Color{
+		Red:   float64(nrgba.R) / 255.0,
+		Green: float64(nrgba.G) / 255.0,
+		Blue:  float64(nrgba.B) / 255.0,
+		Alpha: float64(nrgba.A) / 255.0,
+	}
+
+	if nrgba.A == 0 {
+		sc.ForceSendFields = []string{"Alpha"}
+	}
+
+	return sc
+}
+
+// unitToByte converts a 0.0-1.0 component to 0-255, clamping out-of-range values.
+func unitToByte(v float64) uint8 {
+	return uint8(math.Round(max(0, min(1, v)) * 255))
+}
+
+// ContrastingText returns black or white, whichever is more readable on bg.
+// A nil bg is treated as white (the default background).
+func ContrastingText(bg *sheets.Color) *sheets.Color {
+	black := &sheets.Color{Alpha: 1.0}
+	white := &sheets.Color{Red: 1.0, Green: 1.0, Blue: 1.0, Alpha: 1.0}
+
+	if bg == nil {
+		return black
+	}
+
+	// WCAG の相対輝度。0.179 付近が黒白のコントラスト比が等しくなる境目
+	l := 0.2126*linearize(bg.Red) + 0.7152*linearize(bg.Green) + 0.0722*linearize(bg.Blue)
+
+	if l > 0.179 {
+		return black
+	}
+
+	return white
+}
+
+// linearize converts an sRGB component (0.0-1.0) to linear light.
+func linearize(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// LambdaFormula composes a LAMBDA formula (e.g. "=LAMBDA(x, y, x*y)") from parameter names and a body.
+// The Sheets API does not expose named functions, so use the result with SetCellValue
+// or paste it into the named function dialog. A leading "=" in body is ignored.
+func LambdaFormula(params []string, body string) (string, error) {
+	body = strings.TrimPrefix(body, "=")
+
+	if body == "" {
+		return "", errors.New("LambdaFormula: body should not be empty")
+	}
+
+	seen := make(map[string]bool, len(params))
+
+	var sb strings.Builder
+
+	sb.WriteString("=LAMBDA(")
+
+	for _, p := range params {
+		if !isFormulaIdentifier(p) {
+			return "", fmt.Errorf("LambdaFormula: invalid param name: %q", p)
+		}
+
+		key := strings.ToUpper(p)
+
+		if seen[key] {
+			return "", fmt.Errorf("LambdaFormula: duplicate param name: %q", p)
+		}
+
+		seen[key] = true
+
+		sb.WriteString(p)
+		sb.WriteString(", ")
+	}
+
+	sb.WriteString(body)
+	sb.WriteString(")")
+
+	return sb.String(), nil
+}
+
+// isFormulaIdentifier reports whether name can be used as a function or parameter name.
+// It must start with a letter or underscore, contain only letters, digits, underscores
+// and periods, must not look like an A1 or R1C1 cell reference such as "A1" or "R1C1",
+// and must not be TRUE or FALSE.
+func isFormulaIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		isLetter := ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || r == '_'
+		isDigit := '0' <= r && r <= '9'
+
+		if i == 0 && !isLetter {
+			return false
+		}
+
+		if !isLetter && !isDigit && r != '.' {
+			return false
+		}
+	}
+
+	if strings.EqualFold(name, "TRUE") || strings.EqualFold(name, "FALSE") {
+		return false
+	}
+
+	return !looksLikeCellRef(name) && !looksLikeR1C1Ref(name)
+}
+
+// looksLikeR1C1Ref reports whether name has the shape of an R1C1 reference:
+// "R" and/or "C", each optionally followed by digits (e.g. "R1C1", "R2", "C", "RC").
+func looksLikeR1C1Ref(name string) bool {
+	rest := name
+
+	if len(rest) > 0 && (rest[0] == 'R' || rest[0] == 'r') {
+		rest = strings.TrimLeft(rest[1:], "0123456789")
+	}
+
+	if len(rest) > 0 && (rest[0] == 'C' || rest[0] == 'c') {
+		rest = strings.TrimLeft(rest[1:], "0123456789")
+	}
+
+	return len(rest) == 0 && len(name) > 0
+}
+
+// looksLikeCellRef reports whether name has the shape of an A1 cell reference (letters followed by digits).
+func looksLikeCellRef(name string) bool {
+	i := 0
+
+	for i < len(name) && (('a' <= name[i] && name[i] <= 'z') || ('A' <= name[i] && name[i] <= 'Z')) {
+		i++
+	}
+
+	if i == 0 || i == len(name) {
+		return false
+	}
+
+	for j := i; j < len(name); j++ {
+		if name[j] < '0' || name[j] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ToAnyMatrix converts a matrix of any element type into [][]any for SetRangeValues and friends.
+// A nil matrix returns nil.
+func ToAnyMatrix[T any](m [][]T) [][]any {
+	if m == nil {
+		return nil
+	}
+
+	result := make([][]any, len(m))
+
+	for i, row := range m {
+		result[i] = make([]any, len(row))
+
+		for j, v := range row {
+			result[i][j] = v
+		}
+	}
+
+	return result
+}
+
+// sheetsEpoch is day 0 of the spreadsheet serial date system.
+var sheetsEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// timeToSerial converts t to a spreadsheet serial number (days since 1899-12-30 with a fractional time).
+// The wall-clock time in t's own location is used, since spreadsheet dates carry no time zone.
+func timeToSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+
+	// Sub は約292年で溢れるので秒単位で計算する
+	secs := wall.Unix() - sheetsEpoch.Unix()
+
+	return (float64(secs) + float64(wall.Nanosecond())/1e9) / 86400
+}
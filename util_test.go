@@ -0,0 +1,247 @@
+package haresheet
+
+import (
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestGridRangeEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *sheets.GridRange
+		want bool
+	}{
+		{"both nil", nil, nil, true},
+		{"a nil", nil, &sheets.GridRange{}, false},
+		{"b nil", &sheets.GridRange{}, nil, false},
+		{
+			"same bounded",
+			&sheets.GridRange{SheetId: 1, StartRowIndex: 1, EndRowIndex: 5, StartColumnIndex: 2, EndColumnIndex: 4},
+			&sheets.GridRange{SheetId: 1, StartRowIndex: 1, EndRowIndex: 5, StartColumnIndex: 2, EndColumnIndex: 4},
+			true,
+		},
+		{
+			"same open-ended",
+			&sheets.GridRange{SheetId: 1, StartRowIndex: 3},
+			&sheets.GridRange{SheetId: 1, StartRowIndex: 3},
+			true,
+		},
+		{
+			"open vs bounded end",
+			&sheets.GridRange{SheetId: 1, StartRowIndex: 3},
+			&sheets.GridRange{SheetId: 1, StartRowIndex: 3, EndRowIndex: 10},
+			false,
+		},
+		{
+			"different sheet",
+			&sheets.GridRange{SheetId: 1, EndRowIndex: 5},
+			&sheets.GridRange{SheetId: 2, EndRowIndex: 5},
+			false,
+		},
+		{
+			"different start column",
+			&sheets.GridRange{SheetId: 1, StartColumnIndex: 1, EndColumnIndex: 3},
+			&sheets.GridRange{SheetId: 1, StartColumnIndex: 0, EndColumnIndex: 3},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GridRangeEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("GridRangeEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGridRangeContains(t *testing.T) {
+	outer := &sheets.GridRange{SheetId: 1, StartRowIndex: 2, EndRowIndex: 10, StartColumnIndex: 1, EndColumnIndex: 5}
+
+	tests := []struct {
+		name         string
+		outer, inner *sheets.GridRange
+		want         bool
+	}{
+		{"outer nil", nil, &sheets.GridRange{}, false},
+		{"inner nil", outer, nil, false},
+		{"both nil", nil, nil, false},
+		{"identical", outer, &sheets.GridRange{SheetId: 1, StartRowIndex: 2, EndRowIndex: 10, StartColumnIndex: 1, EndColumnIndex: 5}, true},
+		{"strictly inside", outer, &sheets.GridRange{SheetId: 1, StartRowIndex: 3, EndRowIndex: 9, StartColumnIndex: 2, EndColumnIndex: 4}, true},
+		{"touches top-left edge", outer, &sheets.GridRange{SheetId: 1, StartRowIndex: 2, EndRowIndex: 3, StartColumnIndex: 1, EndColumnIndex: 2}, true},
+		{"touches bottom-right edge", outer, &sheets.GridRange{SheetId: 1, StartRowIndex: 9, EndRowIndex: 10, StartColumnIndex: 4, EndColumnIndex: 5}, true},
+		{"one row past end", outer, &sheets.GridRange{SheetId: 1, StartRowIndex: 9, EndRowIndex: 11, StartColumnIndex: 1, EndColumnIndex: 5}, false},
+		{"one column before start", outer, &sheets.GridRange{SheetId: 1, StartRowIndex: 2, EndRowIndex: 10, StartColumnIndex: 0, EndColumnIndex: 5}, false},
+		{"different sheet", outer, &sheets.GridRange{SheetId: 2, StartRowIndex: 3, EndRowIndex: 4, StartColumnIndex: 2, EndColumnIndex: 3}, false},
+		{"bounded outer, open inner rows", outer, &sheets.GridRange{SheetId: 1, StartRowIndex: 3, StartColumnIndex: 2, EndColumnIndex: 3}, false},
+		{"open outer contains bounded", &sheets.GridRange{SheetId: 1}, &sheets.GridRange{SheetId: 1, StartRowIndex: 100, EndRowIndex: 200, StartColumnIndex: 5, EndColumnIndex: 6}, true},
+		{"open outer contains open", &sheets.GridRange{SheetId: 1, StartRowIndex: 2}, &sheets.GridRange{SheetId: 1, StartRowIndex: 5}, true},
+		{"open outer, inner starts before", &sheets.GridRange{SheetId: 1, StartRowIndex: 2}, &sheets.GridRange{SheetId: 1, StartRowIndex: 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GridRangeContains(tt.outer, tt.inner); got != tt.want {
+				t.Errorf("GridRangeContains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColIndexToLetters(t *testing.T) {
+	tests := []struct {
+		col  int
+		want string
+	}{
+		{0, "A"},
+		{1, "B"},
+		{25, "Z"},
+		{26, "AA"},
+		{27, "AB"},
+		{51, "AZ"},
+		{52, "BA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+		{16383, "XFD"},
+		{18277, "ZZZ"},
+		{18278, "AAAA"},
+	}
+
+	for _, tt := range tests {
+		if got := string(ColIndexToLetters(tt.col)); got != tt.want {
+			t.Errorf("ColIndexToLetters(%d) = %q, want %q", tt.col, got, tt.want)
+		}
+
+		got, err := ColIndexToLettersSafe(tt.col)
+		if err != nil || got != tt.want {
+			t.Errorf("ColIndexToLettersSafe(%d) = %q, %v, want %q", tt.col, got, err, tt.want)
+		}
+	}
+}
+
+func TestColIndexToLettersNegative(t *testing.T) {
+	if got := ColIndexToLetters(-1); got != nil {
+		t.Errorf("ColIndexToLetters(-1) = %q, want nil", got)
+	}
+
+	if _, err := ColIndexToLettersSafe(-1); err != ErrNegativeIndex {
+		t.Errorf("ColIndexToLettersSafe(-1) error = %v, want ErrNegativeIndex", err)
+	}
+}
+
+// lettersToColIndex is the inverse of ColIndexToLetters, used to check round trips.
+func lettersToColIndex(s string) int {
+	n := 0
+
+	for i := 0; i < len(s); i++ {
+		n = n*26 + int(s[i]-'A') + 1
+	}
+
+	return n - 1
+}
+
+func TestColIndexToLettersRoundTrip(t *testing.T) {
+	for col := 0; col < 20000; col++ {
+		letters := ColIndexToLetters(col)
+
+		safe, err := ColIndexToLettersSafe(col)
+		if err != nil {
+			t.Fatalf("ColIndexToLettersSafe(%d) error = %v", col, err)
+		}
+
+		if safe != string(letters) {
+			t.Fatalf("ColIndexToLettersSafe(%d) = %q, ColIndexToLetters = %q", col, safe, letters)
+		}
+
+		if got := lettersToColIndex(safe); got != col {
+			t.Fatalf("round trip of %d via %q = %d", col, safe, got)
+		}
+	}
+
+	// 大きな値でも逆変換できること
+	const large = 1 << 40
+
+	if got := lettersToColIndex(string(ColIndexToLetters(large))); got != large {
+		t.Errorf("round trip of %d = %d", large, got)
+	}
+}
+
+func TestLambdaFormulaParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []string
+		wantErr bool
+	}{
+		{"plain names", []string{"x", "price", "tax_rate", "v.1"}, false},
+		{"single letter R-like word", []string{"rate", "cost"}, false},
+		{"A1 reference", []string{"A1"}, true},
+		{"lower a1 reference", []string{"ab12"}, true},
+		{"R1C1 reference", []string{"R1C1"}, true},
+		{"R reference", []string{"R"}, true},
+		{"C reference", []string{"c"}, true},
+		{"RC reference", []string{"RC"}, true},
+		{"R2 reference", []string{"R2"}, true},
+		{"C10 reference", []string{"C10"}, true},
+		{"TRUE", []string{"true"}, true},
+		{"duplicate", []string{"x", "y", "x"}, true},
+		{"duplicate different case", []string{"x", "X"}, true},
+		{"starts with digit", []string{"1x"}, true},
+		{"empty", []string{""}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LambdaFormula(tt.params, "1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LambdaFormula(%q) error = %v, wantErr %v", tt.params, err, tt.wantErr)
+			}
+		})
+	}
+
+	got, err := LambdaFormula([]string{"x", "y"}, "=x*y")
+	if err != nil || got != "=LAMBDA(x, y, x*y)" {
+		t.Errorf("LambdaFormula() = %q, %v", got, err)
+	}
+}
+
+func TestIndexToA1(t *testing.T) {
+	tests := []struct {
+		row, col int
+		abs      AbsMode
+		want     string
+	}{
+		{0, 0, AbsNone, "A1"},
+		{9, 27, AbsNone, "AB10"},
+		{0, 0, AbsBoth, "$A$1"},
+		{4, 2, AbsCol, "$C5"},
+		{4, 2, AbsRow, "C$5"},
+	}
+
+	for _, tt := range tests {
+		got, err := IndexToA1AtAbs(tt.row, tt.col, tt.abs)
+		if err != nil || got != tt.want {
+			t.Errorf("IndexToA1AtAbs(%d, %d, %d) = %q, %v; want %q", tt.row, tt.col, tt.abs, got, err, tt.want)
+		}
+	}
+
+	if _, err := IndexToA1(0, 0, 0, -1); err != ErrNegativeIndex {
+		t.Errorf("IndexToA1 with negative col: err = %v, want ErrNegativeIndex", err)
+	}
+}
+
+func BenchmarkIndexToA1(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = IndexToA1(i%1000, i%700, 0, 0)
+	}
+}
+
+func BenchmarkIndexToA1Abs(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = IndexToA1Abs(i%1000, i%700, 0, 0, AbsBoth)
+	}
+}